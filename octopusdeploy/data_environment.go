@@ -24,6 +24,14 @@ func dataEnvironment() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"sort_order": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"allow_dynamic_infrastructure": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -46,6 +54,8 @@ func dataEnvironmentReadByName(d *schema.ResourceData, m interface{}) error {
 	d.Set("name", env.Name)
 	d.Set("description", env.Description)
 	d.Set("use_guided_failure", env.UseGuidedFailure)
+	d.Set("sort_order", env.SortOrder)
+	d.Set("allow_dynamic_infrastructure", env.AllowDynamicInfrastructure)
 
 	return nil
 }
@@ -0,0 +1,62 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataProjectGroup() *schema.Resource {
+	return &schema.Resource{
+		Read: dataProjectGroupReadByName,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"environment_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"retention_policy_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataProjectGroupReadByName(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	projectGroupName := d.Get("name")
+
+	projectGroup, err := client.ProjectGroup.GetByName(projectGroupName.(string))
+
+	if err == octopusdeploy.ErrItemNotFound {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading projectgroup name %s: %s", projectGroupName, err.Error())
+	}
+
+	d.SetId(projectGroup.ID)
+
+	log.Printf("[DEBUG] projectgroup: %v", m)
+	d.Set("name", projectGroup.Name)
+	d.Set("description", projectGroup.Description)
+	d.Set("environment_ids", projectGroup.EnvironmentIds)
+	d.Set("retention_policy_id", projectGroup.RetentionPolicyID)
+	return nil
+}
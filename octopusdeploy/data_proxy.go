@@ -0,0 +1,59 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataProxy() *schema.Resource {
+	return &schema.Resource{
+		Read: dataProxyReadByName,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"host": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"username": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataProxyReadByName(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	proxyName := d.Get("name")
+
+	proxy, err := client.Proxy.GetByName(proxyName.(string))
+
+	if err == octopusdeploy.ErrItemNotFound {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading proxy name %s: %s", proxyName, err.Error())
+	}
+
+	d.SetId(proxy.ID)
+
+	log.Printf("[DEBUG] proxy: %v", m)
+	d.Set("name", proxy.Name)
+	d.Set("host", proxy.Host)
+	d.Set("port", proxy.Port)
+	d.Set("username", proxy.Username)
+	return nil
+}
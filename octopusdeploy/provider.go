@@ -12,6 +12,8 @@ func Provider() terraform.ResourceProvider {
 	return &schema.Provider{
 		DataSourcesMap: map[string]*schema.Resource{
 			"octopusdeploy_project":       dataProject(),
+			"octopusdeploy_project_group": dataProjectGroup(),
+			"octopusdeploy_proxy":         dataProxy(),
 			"octopusdeploy_environment":   dataEnvironment(),
 			"octopusdeploy_variable":      dataVariable(),
 			"octopusdeploy_machinepolicy": dataMachinePolicy(),
@@ -23,11 +25,22 @@ func Provider() terraform.ResourceProvider {
 			"octopusdeploy_project":                           resourceProject(),
 			"octopusdeploy_project_group":                     resourceProjectGroup(),
 			"octopusdeploy_project_deployment_target_trigger": resourceProjectDeploymentTargetTrigger(),
+			"octopusdeploy_proxy":                             resourceProxy(),
 			"octopusdeploy_environment":                       resourceEnvironment(),
+			"octopusdeploy_environment_sort_order":            resourceEnvironmentSortOrder(),
 			"octopusdeploy_variable":                          resourceVariable(),
 			"octopusdeploy_machine":                           resourceMachine(),
 			"octopusdeploy_library_variable_set":              resourceLibraryVariableSet(),
 			"octopusdeploy_lifecycle":                         resourceLifecycle(),
+			"octopusdeploy_release":                           resourceRelease(),
+			"octopusdeploy_deployment":                        resourceDeployment(),
+			"octopusdeploy_runbook":                           resourceRunbook(),
+			"octopusdeploy_subscription":                      resourceSubscription(),
+			"octopusdeploy_smtp_configuration":                resourceSmtpConfiguration(),
+			"octopusdeploy_maintenance_configuration":         resourceMaintenanceConfiguration(),
+			"octopusdeploy_server_folders_configuration":      resourceServerFoldersConfiguration(),
+			"octopusdeploy_builtin_repository_configuration":  resourceBuiltInRepositoryConfiguration(),
+			"octopusdeploy_license":                           resourceLicense(),
 		},
 		Schema: map[string]*schema.Schema{
 			"address": &schema.Schema{
@@ -0,0 +1,78 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// builtInRepositoryConfigurationID is the ID of the built-in repository settings, of which there is only one per
+// Octopus server
+const builtInRepositoryConfigurationID = "builtin-repository"
+
+func resourceBuiltInRepositoryConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBuiltInRepositoryConfigurationUpdate,
+		Read:   resourceBuiltInRepositoryConfigurationRead,
+		Update: resourceBuiltInRepositoryConfigurationUpdate,
+		Delete: resourceServerConfigurationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"delete_unreleased_packages_after_days": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validateIntAtLeastFunc(0),
+				Description:  "The number of days packages no release uses are kept in the built-in repository. If 0 they are kept forever.",
+			},
+		},
+	}
+}
+
+func resourceBuiltInRepositoryConfigurationRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	builtInRepository, err := client.Configuration.GetBuiltInRepository()
+
+	if err != nil {
+		return fmt.Errorf("error reading built-in repository configuration: %s", err.Error())
+	}
+
+	log.Printf("[DEBUG] built-in repository configuration: %v", m)
+
+	if builtInRepository.DeleteUnreleasedPackagesAfterDays != nil {
+		d.Set("delete_unreleased_packages_after_days", *builtInRepository.DeleteUnreleasedPackagesAfterDays)
+	} else {
+		d.Set("delete_unreleased_packages_after_days", 0)
+	}
+
+	return nil
+}
+
+func resourceBuiltInRepositoryConfigurationUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	// start from the settings in Octopus, so settings this resource does not manage are left as they are
+	builtInRepository, err := client.Configuration.GetBuiltInRepository()
+
+	if err != nil {
+		return fmt.Errorf("error reading built-in repository configuration: %s", err.Error())
+	}
+
+	// packages are kept forever when no number of days is sent
+	builtInRepository.DeleteUnreleasedPackagesAfterDays = nil
+
+	if days := d.Get("delete_unreleased_packages_after_days").(int); days > 0 {
+		builtInRepository.DeleteUnreleasedPackagesAfterDays = &days
+	}
+
+	if _, err := client.Configuration.UpdateBuiltInRepository(builtInRepository); err != nil {
+		return fmt.Errorf("error updating built-in repository configuration: %s", err.Error())
+	}
+
+	d.SetId(builtInRepositoryConfigurationID)
+
+	return resourceBuiltInRepositoryConfigurationRead(d, m)
+}
@@ -0,0 +1,42 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccOctopusDeployBuiltInRepositoryConfiguration(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_builtin_repository_configuration.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBuiltInRepositoryConfiguration(30),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "delete_unreleased_packages_after_days", "30"),
+				),
+			},
+			{
+				Config: testAccBuiltInRepositoryConfiguration(0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "delete_unreleased_packages_after_days", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccBuiltInRepositoryConfiguration(days int) string {
+	return fmt.Sprintf(`
+		resource "octopusdeploy_builtin_repository_configuration" "foo" {
+			delete_unreleased_packages_after_days = %d
+		}
+		`,
+		days,
+	)
+}
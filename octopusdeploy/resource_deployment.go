@@ -0,0 +1,226 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceDeployment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDeploymentCreate,
+		Read:   resourceDeploymentRead,
+		Update: resourceDeploymentUpdate,
+		Delete: resourceDeploymentDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"release_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the release to deploy.",
+			},
+			"environment_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the environment to deploy to.",
+			},
+			"tenant_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The ID of the tenant to deploy for.",
+			},
+			"comments": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Comments about the deployment.",
+			},
+			"form_values": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Values for prompted variables, keyed by the ID of the variable. Only sent when the deployment is queued, as Octopus does not return sensitive values.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"skip_actions": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The IDs of the actions (steps) to skip.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"specific_machine_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Only deploy to these machines. Deploys to all machines in the environment when empty.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"excluded_machine_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Do not deploy to these machines.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"force_package_download": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Download packages again even if they are already on the deployment target.",
+			},
+			"force_package_redeployment": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Redeploy packages even if they are already installed on the deployment target.",
+			},
+			"use_guided_failure": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Ask for a human to intervene if the deployment fails.",
+			},
+			"wait_for_completion": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait for the deployment task to finish, failing if the deployment fails.",
+			},
+			"task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the server task running the deployment.",
+			},
+		},
+	}
+}
+
+func buildDeploymentResource(d *schema.ResourceData) *octopusdeploy.Deployment {
+	releaseID := d.Get("release_id").(string)
+	environmentID := d.Get("environment_id").(string)
+
+	deployment := octopusdeploy.NewDeployment(releaseID, environmentID)
+
+	deployment.TenantID = d.Get("tenant_id").(string)
+	deployment.Comments = d.Get("comments").(string)
+	deployment.ForcePackageDownload = d.Get("force_package_download").(bool)
+	deployment.ForcePackageRedeployment = d.Get("force_package_redeployment").(bool)
+	deployment.UseGuidedFailure = d.Get("use_guided_failure").(bool)
+
+	for key, value := range d.Get("form_values").(map[string]interface{}) {
+		deployment.FormValues[key] = value.(string)
+	}
+
+	if attr, ok := d.GetOk("skip_actions"); ok {
+		deployment.SkipActions = getSliceFromTerraformTypeList(attr)
+	}
+
+	if attr, ok := d.GetOk("specific_machine_ids"); ok {
+		deployment.SpecificMachineIDs = getSliceFromTerraformTypeList(attr)
+	}
+
+	if attr, ok := d.GetOk("excluded_machine_ids"); ok {
+		deployment.ExcludedMachineIDs = getSliceFromTerraformTypeList(attr)
+	}
+
+	return deployment
+}
+
+func resourceDeploymentCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	newDeployment := buildDeploymentResource(d)
+
+	createdDeployment, err := client.Deployment.Add(newDeployment)
+
+	if err != nil {
+		return fmt.Errorf("error creating deployment of release %s to environment %s: %s", newDeployment.ReleaseID, newDeployment.EnvironmentID, err.Error())
+	}
+
+	d.SetId(createdDeployment.ID)
+	d.Set("task_id", createdDeployment.TaskID)
+
+	if d.Get("wait_for_completion").(bool) {
+		if _, err := waitForServerTask(client, createdDeployment.TaskID, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return fmt.Errorf("error waiting for deployment id %s: %s", createdDeployment.ID, err.Error())
+		}
+	}
+
+	return resourceDeploymentRead(d, m)
+}
+
+func resourceDeploymentRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	deploymentID := d.Id()
+
+	deployment, err := client.Deployment.Get(deploymentID)
+
+	if err == octopusdeploy.ErrItemNotFound {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading deployment id %s: %s", deploymentID, err.Error())
+	}
+
+	log.Printf("[DEBUG] deployment: %v", m)
+	d.Set("release_id", deployment.ReleaseID)
+	d.Set("environment_id", deployment.EnvironmentID)
+	d.Set("tenant_id", deployment.TenantID)
+	d.Set("comments", deployment.Comments)
+	d.Set("skip_actions", deployment.SkipActions)
+	d.Set("specific_machine_ids", deployment.SpecificMachineIDs)
+	d.Set("excluded_machine_ids", deployment.ExcludedMachineIDs)
+	d.Set("force_package_download", deployment.ForcePackageDownload)
+	d.Set("force_package_redeployment", deployment.ForcePackageRedeployment)
+	d.Set("use_guided_failure", deployment.UseGuidedFailure)
+	d.Set("task_id", deployment.TaskID)
+
+	// form_values are not read back, as Octopus does not return the values of sensitive prompted variables
+
+	return nil
+}
+
+// resourceDeploymentUpdate only updates settings stored in the state, as a deployment cannot be changed once queued
+func resourceDeploymentUpdate(d *schema.ResourceData, m interface{}) error {
+	return resourceDeploymentRead(d, m)
+}
+
+func resourceDeploymentDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	deploymentID := d.Id()
+
+	err := client.Deployment.Delete(deploymentID)
+
+	if err != nil {
+		return fmt.Errorf("error deleting deployment id %s: %s", deploymentID, err.Error())
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,117 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccOctopusDeployDeploymentWaitForCompletion(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_deployment.foo"
+	taskIDRegex, _ := regexp.Compile("ServerTasks\\-")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeploymentWaitForCompletion("exit 0"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployDeploymentExists(terraformNamePrefix),
+					resource.TestMatchResourceAttr(
+						terraformNamePrefix, "task_id", taskIDRegex),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "wait_for_completion", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployDeploymentFailure(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDeploymentWaitForCompletion("exit 1"),
+				ExpectError: regexp.MustCompile("finished with state Failed"),
+			},
+		},
+	})
+}
+
+func testAccDeploymentWaitForCompletion(scriptBody string) string {
+	return fmt.Sprintf(`
+		resource "octopusdeploy_environment" "foo" {
+			name = "Funky Deployment Environment"
+		}
+
+		resource "octopusdeploy_project" "foo" {
+			name             = "Funky Deployment Project"
+			lifecycle_id     = "Lifecycles-1"
+			project_group_id = "ProjectGroups-1"
+
+			deployment_step_inline_script {
+				step_name     = "Run Script"
+				script_type   = "PowerShell"
+				script_body   = "%s"
+				run_on_server = true
+			}
+		}
+
+		resource "octopusdeploy_release" "foo" {
+			project_id = "${octopusdeploy_project.foo.id}"
+			version    = "1.0.0"
+		}
+
+		resource "octopusdeploy_deployment" "foo" {
+			release_id          = "${octopusdeploy_release.foo.id}"
+			environment_id      = "${octopusdeploy_environment.foo.id}"
+			comments            = "Deployed by Terraform"
+			wait_for_completion = true
+		}
+		`,
+		scriptBody,
+	)
+}
+
+func testAccCheckOctopusDeployDeploymentDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*octopusdeploy.Client)
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "octopusdeploy_deployment" {
+			continue
+		}
+
+		if _, err := client.Deployment.Get(r.Primary.ID); err != nil {
+			if err == octopusdeploy.ErrItemNotFound {
+				continue
+			}
+			return fmt.Errorf("Received an error retrieving deployment %s", err)
+		}
+		return fmt.Errorf("Deployment still exists")
+	}
+	return nil
+}
+
+func testAccCheckOctopusDeployDeploymentExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*octopusdeploy.Client)
+
+		r, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if _, err := client.Deployment.Get(r.Primary.ID); err != nil {
+			return fmt.Errorf("Received an error retrieving deployment %s", err)
+		}
+		return nil
+	}
+}
@@ -28,10 +28,56 @@ func resourceEnvironment() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"sort_order": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The position of the environment amongst the other environments. New environments are added last. Do not set this on environments ordered by octopusdeploy_environment_sort_order, as the two would overwrite each other.",
+			},
+			"allow_dynamic_infrastructure": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow deployment targets to be created in this environment by deployments, such as with New-OctopusAzureWebAppTarget.",
+			},
+			"jira_extension_settings": &schema.Schema{
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"environment_type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The type of environment deployments are reported to Jira as.",
+							ValidateFunc: validateValueFunc(octopusdeploy.ValidJiraEnvironmentTypes),
+						},
+					},
+				},
+			},
+			"servicenow_extension_settings": &schema.Schema{
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"is_change_controlled": {
+							Type:        schema.TypeBool,
+							Required:    true,
+							Description: "Whether deployments to this environment need an approved ServiceNow change request.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+const (
+	jiraExtensionID       = "jira-integration"
+	serviceNowExtensionID = "servicenow-integration"
+)
+
 func resourceEnvironmentRead(d *schema.ResourceData, m interface{}) error {
 	client := m.(*octopusdeploy.Client)
 
@@ -50,27 +96,83 @@ func resourceEnvironmentRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("name", env.Name)
 	d.Set("description", env.Description)
 	d.Set("use_guided_failure", env.UseGuidedFailure)
+	d.Set("sort_order", env.SortOrder)
+	d.Set("allow_dynamic_infrastructure", env.AllowDynamicInfrastructure)
+
+	var tfJiraSettings []interface{}
+	var tfServiceNowSettings []interface{}
+
+	for _, extensionSettings := range env.ExtensionSettings {
+		switch extensionSettings.ExtensionID {
+		case jiraExtensionID:
+			environmentType, _ := extensionSettings.Values["JiraEnvironmentType"].(string)
+
+			tfJiraSettings = append(tfJiraSettings, map[string]interface{}{
+				"environment_type": environmentType,
+			})
+		case serviceNowExtensionID:
+			isChangeControlled, _ := extensionSettings.Values["ServiceNowChangeControlled"].(bool)
+
+			tfServiceNowSettings = append(tfServiceNowSettings, map[string]interface{}{
+				"is_change_controlled": isChangeControlled,
+			})
+		}
+	}
+
+	d.Set("jira_extension_settings", tfJiraSettings)
+	d.Set("servicenow_extension_settings", tfServiceNowSettings)
 
 	return nil
 }
 
 func buildEnvironmentResource(d *schema.ResourceData) *octopusdeploy.Environment {
-	envName := d.Get("name").(string)
+	env := octopusdeploy.NewEnvironment(d.Get("name").(string), d.Get("description").(string), d.Get("use_guided_failure").(bool))
 
-	var envDesc string
-	var envGuided bool
+	setEnvironmentValues(d, env)
 
-	envDescInterface, ok := d.GetOk("description")
-	if ok {
-		envDesc = envDescInterface.(string)
+	return env
+}
+
+// setEnvironmentValues copies the values managed by Terraform onto an environment. The settings of extensions
+// Terraform does not manage are left alone.
+func setEnvironmentValues(d *schema.ResourceData, env *octopusdeploy.Environment) {
+	env.Name = d.Get("name").(string)
+	env.Description = d.Get("description").(string)
+	env.UseGuidedFailure = d.Get("use_guided_failure").(bool)
+	env.AllowDynamicInfrastructure = d.Get("allow_dynamic_infrastructure").(bool)
+
+	// only send a changed sort order, so one set by octopusdeploy_environment_sort_order is not put back
+	if sortOrder, ok := d.GetOk("sort_order"); ok && d.HasChange("sort_order") {
+		env.SortOrder = sortOrder.(int)
 	}
 
-	envGuidedInterface, ok := d.GetOk("use_guided_failure")
-	if ok {
-		envGuided = envGuidedInterface.(bool)
+	var extensionSettings []octopusdeploy.ExtensionSettingsValue
+
+	for _, existingSettings := range env.ExtensionSettings {
+		if existingSettings.ExtensionID != jiraExtensionID && existingSettings.ExtensionID != serviceNowExtensionID {
+			extensionSettings = append(extensionSettings, existingSettings)
+		}
 	}
 
-	return octopusdeploy.NewEnvironment(envName, envDesc, envGuided)
+	if tfJiraSettings := d.Get("jira_extension_settings").([]interface{}); len(tfJiraSettings) > 0 && tfJiraSettings[0] != nil {
+		extensionSettings = append(extensionSettings, octopusdeploy.ExtensionSettingsValue{
+			ExtensionID: jiraExtensionID,
+			Values: map[string]interface{}{
+				"JiraEnvironmentType": tfJiraSettings[0].(map[string]interface{})["environment_type"].(string),
+			},
+		})
+	}
+
+	if tfServiceNowSettings := d.Get("servicenow_extension_settings").([]interface{}); len(tfServiceNowSettings) > 0 && tfServiceNowSettings[0] != nil {
+		extensionSettings = append(extensionSettings, octopusdeploy.ExtensionSettingsValue{
+			ExtensionID: serviceNowExtensionID,
+			Values: map[string]interface{}{
+				"ServiceNowChangeControlled": tfServiceNowSettings[0].(map[string]interface{})["is_change_controlled"].(bool),
+			},
+		})
+	}
+
+	env.ExtensionSettings = extensionSettings
 }
 
 func resourceEnvironmentCreate(d *schema.ResourceData, m interface{}) error {
@@ -89,11 +191,17 @@ func resourceEnvironmentCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceEnvironmentUpdate(d *schema.ResourceData, m interface{}) error {
-	env := buildEnvironmentResource(d)
-	env.ID = d.Id() // set project struct ID so octopus knows which project to update
-
 	client := m.(*octopusdeploy.Client)
 
+	// start from the environment as it is in Octopus, so the settings of other extensions are sent back unchanged
+	env, err := client.Environment.Get(d.Id())
+
+	if err != nil {
+		return fmt.Errorf("error reading environment id %s: %s", d.Id(), err.Error())
+	}
+
+	setEnvironmentValues(d, env)
+
 	updatedEnv, err := client.Environment.Update(env)
 
 	if err != nil {
@@ -0,0 +1,134 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// environmentSortOrderID is the ID of the environment sort order, of which there is only one per Octopus server
+const environmentSortOrderID = "environment-sort-order"
+
+func resourceEnvironmentSortOrder() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceEnvironmentSortOrderCreate,
+		Read:   resourceEnvironmentSortOrderRead,
+		Update: resourceEnvironmentSortOrderCreate,
+		Delete: resourceEnvironmentSortOrderDelete,
+
+		Schema: map[string]*schema.Schema{
+			"environment_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "The IDs of the environments in the order they are shown in. Environments not listed are kept after them, in their current order.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// getSortedEnvironments returns all environments in their current order
+func getSortedEnvironments(client *octopusdeploy.Client) ([]octopusdeploy.Environment, error) {
+	environments, err := client.Environment.GetAll()
+
+	if err != nil {
+		return nil, err
+	}
+
+	sortedEnvironments := *environments
+
+	sort.SliceStable(sortedEnvironments, func(i, j int) bool { return sortedEnvironments[i].SortOrder < sortedEnvironments[j].SortOrder })
+
+	return sortedEnvironments, nil
+}
+
+func resourceEnvironmentSortOrderCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	environmentIDs := getSliceFromTerraformTypeList(d.Get("environment_ids"))
+
+	environments, err := getSortedEnvironments(client)
+
+	if err != nil {
+		return fmt.Errorf("error reading environments: %s", err.Error())
+	}
+
+	listed := make(map[string]bool)
+
+	for _, environmentID := range environmentIDs {
+		if listed[environmentID] {
+			return fmt.Errorf("environment %s is listed more than once in environment_ids", environmentID)
+		}
+
+		listed[environmentID] = true
+	}
+
+	for environmentID := range listed {
+		found := false
+
+		for _, environment := range environments {
+			if environment.ID == environmentID {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("environment %s in environment_ids does not exist", environmentID)
+		}
+	}
+
+	// Octopus needs every environment to be given a position
+	for _, environment := range environments {
+		if !listed[environment.ID] {
+			environmentIDs = append(environmentIDs, environment.ID)
+		}
+	}
+
+	if err := client.Environment.SortOrder(environmentIDs); err != nil {
+		return fmt.Errorf("error setting environment sort order: %s", err.Error())
+	}
+
+	d.SetId(environmentSortOrderID)
+
+	return nil
+}
+
+func resourceEnvironmentSortOrderRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	environments, err := getSortedEnvironments(client)
+
+	if err != nil {
+		return fmt.Errorf("error reading environments: %s", err.Error())
+	}
+
+	listed := make(map[string]bool)
+
+	for _, environmentID := range getSliceFromTerraformTypeList(d.Get("environment_ids")) {
+		listed[environmentID] = true
+	}
+
+	// only the listed environments are read back, so environments added later do not show as a change
+	var environmentIDs []string
+
+	for _, environment := range environments {
+		if listed[environment.ID] {
+			environmentIDs = append(environmentIDs, environment.ID)
+		}
+	}
+
+	d.Set("environment_ids", environmentIDs)
+
+	return nil
+}
+
+// resourceEnvironmentSortOrderDelete only removes the sort order from the state, as environments always have an order
+func resourceEnvironmentSortOrderDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
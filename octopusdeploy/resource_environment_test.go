@@ -35,6 +35,110 @@ func TestAccOctopusDeployEnvironmentBasic(t *testing.T) {
 	})
 }
 
+func TestAccOctopusDeployEnvironmentWithSettings(t *testing.T) {
+	const envPrefix = "octopusdeploy_environment.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testOctopusDeployEnvironmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testEnvironmentWithSettings("production", true),
+				Check: resource.ComposeTestCheckFunc(
+					testOctopusDeployEnvironmentExists(envPrefix),
+					resource.TestCheckResourceAttr(
+						envPrefix, "allow_dynamic_infrastructure", "true"),
+					resource.TestCheckResourceAttr(
+						envPrefix, "jira_extension_settings.0.environment_type", "production"),
+					resource.TestCheckResourceAttr(
+						envPrefix, "servicenow_extension_settings.0.is_change_controlled", "true"),
+					resource.TestCheckResourceAttrSet(
+						envPrefix, "sort_order"),
+				),
+			},
+			{
+				Config: testEnvironmentWithSettings("staging", false),
+				Check: resource.ComposeTestCheckFunc(
+					testOctopusDeployEnvironmentExists(envPrefix),
+					resource.TestCheckResourceAttr(
+						envPrefix, "jira_extension_settings.0.environment_type", "staging"),
+					resource.TestCheckResourceAttr(
+						envPrefix, "servicenow_extension_settings.0.is_change_controlled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployEnvironmentSortOrder(t *testing.T) {
+	const sortOrderPrefix = "octopusdeploy_environment_sort_order.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testOctopusDeployEnvironmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testEnvironmentSortOrder("dev", "prod"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						sortOrderPrefix, "environment_ids.0", "octopusdeploy_environment.dev", "id"),
+					resource.TestCheckResourceAttrPair(
+						sortOrderPrefix, "environment_ids.1", "octopusdeploy_environment.prod", "id"),
+				),
+			},
+			{
+				Config: testEnvironmentSortOrder("prod", "dev"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						sortOrderPrefix, "environment_ids.0", "octopusdeploy_environment.prod", "id"),
+					resource.TestCheckResourceAttrPair(
+						sortOrderPrefix, "environment_ids.1", "octopusdeploy_environment.dev", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testEnvironmentWithSettings(jiraEnvironmentType string, changeControlled bool) string {
+	return fmt.Sprintf(`
+		resource "octopusdeploy_environment" "foo" {
+			name                         = "Production"
+			allow_dynamic_infrastructure = true
+
+			jira_extension_settings {
+				environment_type = "%s"
+			}
+
+			servicenow_extension_settings {
+				is_change_controlled = %t
+			}
+		}
+		`,
+		jiraEnvironmentType, changeControlled,
+	)
+}
+
+func testEnvironmentSortOrder(first, second string) string {
+	return fmt.Sprintf(`
+		resource "octopusdeploy_environment" "prod" {
+			name = "Production"
+		}
+
+		resource "octopusdeploy_environment" "dev" {
+			name = "Development"
+		}
+
+		resource "octopusdeploy_environment_sort_order" "foo" {
+			environment_ids = [
+				"${octopusdeploy_environment.%s.id}",
+				"${octopusdeploy_environment.%s.id}",
+			]
+		}
+		`,
+		first, second,
+	)
+}
+
 func testEnvironmenttBasic(name, description, useguided string) string {
 	return fmt.Sprintf(`
 		resource "octopusdeploy_environment" "foo" {
@@ -56,6 +160,10 @@ func testOctopusDeployEnvironmentExists(n string) resource.TestCheckFunc {
 
 func existsEnvHelper(s *terraform.State, client *octopusdeploy.Client) error {
 	for _, r := range s.RootModule().Resources {
+		if r.Type != "octopusdeploy_environment" {
+			continue
+		}
+
 		if _, err := client.Environment.Get(r.Primary.ID); err != nil {
 			return fmt.Errorf("Received an error retrieving environment %s", err)
 		}
@@ -70,6 +178,10 @@ func testOctopusDeployEnvironmentDestroy(s *terraform.State) error {
 
 func destroyEnvHelper(s *terraform.State, client *octopusdeploy.Client) error {
 	for _, r := range s.RootModule().Resources {
+		if r.Type != "octopusdeploy_environment" {
+			continue
+		}
+
 		if _, err := client.Environment.Get(r.Primary.ID); err != nil {
 			if err == octopusdeploy.ErrItemNotFound {
 				continue
@@ -0,0 +1,70 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// licenseID is the ID of the license, of which there is only one per Octopus server
+const licenseID = "licenses-current"
+
+func resourceLicense() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLicenseUpdate,
+		Read:   resourceLicenseRead,
+		Update: resourceLicenseUpdate,
+		Delete: resourceServerConfigurationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"license_text": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The XML of the license.",
+				// Octopus may reformat the XML, so only differences other than surrounding whitespace are changes
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.TrimSpace(old) == strings.TrimSpace(new)
+				},
+			},
+		},
+	}
+}
+
+func resourceLicenseRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	license, err := client.Configuration.GetLicense()
+
+	if err != nil {
+		return fmt.Errorf("error reading license: %s", err.Error())
+	}
+
+	log.Printf("[DEBUG] license: %v", m)
+	d.Set("license_text", license.LicenseText)
+
+	return nil
+}
+
+func resourceLicenseUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	license, err := client.Configuration.GetLicense()
+
+	if err != nil {
+		return fmt.Errorf("error reading license: %s", err.Error())
+	}
+
+	license.LicenseText = d.Get("license_text").(string)
+
+	if _, err := client.Configuration.UpdateLicense(license); err != nil {
+		return fmt.Errorf("error updating license: %s", err.Error())
+	}
+
+	d.SetId(licenseID)
+
+	return resourceLicenseRead(d, m)
+}
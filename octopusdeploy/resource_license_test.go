@@ -0,0 +1,46 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// TestAccOctopusDeployLicense needs OCTOPUS_LICENSE to be set to the XML of a license the server accepts, so it does
+// not replace the license of the test server with an invalid one
+func TestAccOctopusDeployLicense(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_license.foo"
+	licenseText := os.Getenv("OCTOPUS_LICENSE")
+
+	if licenseText == "" {
+		t.Skip("OCTOPUS_LICENSE must be set to test the license")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLicense(licenseText),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "id", "licenses-current"),
+				),
+			},
+		},
+	})
+}
+
+func testAccLicense(licenseText string) string {
+	return fmt.Sprintf(`
+		resource "octopusdeploy_license" "foo" {
+			license_text = <<EOT
+%s
+EOT
+		}
+		`,
+		licenseText,
+	)
+}
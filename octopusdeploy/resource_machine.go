@@ -2,6 +2,8 @@ package octopusdeploy
 
 import (
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -14,6 +16,10 @@ func resourceMachine() *schema.Resource {
 		Update: resourceMachineUpdate,
 		Delete: resourceMachineDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
@@ -104,6 +110,12 @@ func resourceMachine() *schema.Resource {
 					"Tenanted",
 				}),
 			},
+			"wait_for_health_check": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Run a health check after the machine is created and wait for it to become healthy.",
+			},
 			"tenantids": &schema.Schema{
 				Type: schema.TypeList,
 				Elem: &schema.Schema{
@@ -224,10 +236,104 @@ func resourceMachineCreate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("error creating machine %s: %s", newMachine.Name, err.Error())
 	}
 	d.SetId(machine.ID)
+
+	if d.Get("wait_for_health_check").(bool) {
+		healthyMachine, err := waitForMachineHealth(client, machine.ID, d.Timeout(schema.TimeoutCreate))
+
+		if err != nil {
+			return err
+		}
+
+		machine = healthyMachine
+	}
+
 	setMachineProperties(d, machine)
 	return nil
 }
 
+// maxHealthCheckBackoff is the longest wait between a health check of a machine finishing and the next one being queued
+const maxHealthCheckBackoff = time.Minute
+
+// isMachineHealthy returns whether a machine can be deployed to. Machines with warnings, such as an outdated
+// Tentacle, can still be deployed to.
+func isMachineHealthy(machine *octopusdeploy.Machine) bool {
+	return machine.Status == "Online" || machine.HealthStatus == "Healthy" || machine.HealthStatus == "HasWarnings"
+}
+
+// waitForMachineHealth runs a health check against a machine and polls the machine until it is healthy or the
+// timeout passes. Another health check is only queued once the last one has finished and a backoff has passed, and a
+// health check still running at the timeout is cancelled. The log of the last health check is returned in the error if the machine
+// never becomes healthy.
+func waitForMachineHealth(client *octopusdeploy.Client, machineID string, timeout time.Duration) (*octopusdeploy.Machine, error) {
+	deadline := time.Now().Add(timeout)
+	healthCheckBackoff := taskPollInterval
+	nextHealthCheck := time.Now()
+
+	var healthCheckTask *octopusdeploy.ServerTask
+
+	for {
+		machine, err := client.Machine.Get(machineID)
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading machine %s: %s", machineID, err.Error())
+		}
+
+		if isMachineHealthy(machine) {
+			return machine, nil
+		}
+
+		if time.Now().After(deadline) {
+			if healthCheckTask == nil {
+				return nil, fmt.Errorf("machine %s did not become healthy (%s)", machineID, machine.StatusSummary)
+			}
+
+			if !healthCheckTask.IsCompleted {
+				if _, err := client.Task.Cancel(healthCheckTask.ID); err != nil {
+					log.Printf("[WARN] could not cancel health check %s of machine %s: %s", healthCheckTask.ID, machineID, err.Error())
+				}
+			}
+
+			return nil, fmt.Errorf("machine %s did not become healthy (%s). health check log:\n%s", machineID, machine.StatusSummary, getServerTaskLog(client, healthCheckTask.ID))
+		}
+
+		// health checks that finish without the machine becoming healthy are retried with a growing backoff, and no
+		// new health check is queued when it could not finish before the deadline
+		if healthCheckTask == nil || (healthCheckTask.IsCompleted && time.Now().After(nextHealthCheck) && time.Until(deadline) >= taskPollInterval) {
+			if healthCheckTask != nil {
+				healthCheckBackoff *= 2
+
+				if healthCheckBackoff > maxHealthCheckBackoff {
+					healthCheckBackoff = maxHealthCheckBackoff
+				}
+			}
+
+			healthCheckTask, err = client.Task.Add(octopusdeploy.NewHealthCheckTask(fmt.Sprintf("Check health of %s", machineID), []string{machineID}))
+
+			if err != nil {
+				return nil, fmt.Errorf("error starting health check of machine %s: %s", machineID, err.Error())
+			}
+		}
+
+		// never sleep past the deadline, which is checked again once the machine is read after sleeping
+		sleep := taskPollInterval
+
+		if untilDeadline := time.Until(deadline); untilDeadline < sleep {
+			sleep = untilDeadline
+		}
+
+		time.Sleep(sleep)
+
+		// errors reading the health check are retried on the next poll
+		if task, err := client.Task.Get(healthCheckTask.ID); err == nil {
+			if task.IsCompleted && !healthCheckTask.IsCompleted {
+				nextHealthCheck = time.Now().Add(healthCheckBackoff)
+			}
+
+			healthCheckTask = task
+		}
+	}
+}
+
 func resourceMachineDelete(d *schema.ResourceData, m interface{}) error {
 	client := m.(*octopusdeploy.Client)
 	machineID := d.Id()
@@ -2,6 +2,7 @@ package octopusdeploy
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
@@ -30,6 +31,57 @@ func TestAccOctopusDeployMachineBasic(t *testing.T) {
 	})
 }
 
+func TestAccOctopusDeployMachineWaitForHealthCheckFails(t *testing.T) {
+	const tfMachineName = "octo-terra-test-unreachable"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testOctopusDeployMachineDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testMachineWaitForHealthCheck(tfMachineName),
+				ExpectError: regexp.MustCompile("did not become healthy"),
+			},
+		},
+	})
+}
+
+func testMachineWaitForHealthCheck(machineName string) string {
+	return fmt.Sprintf(`
+	data "octopusdeploy_machinepolicy" "default" {
+		name = "Default Machine Policy"
+	}
+
+	resource "octopusdeploy_environment" "tf_test_env" {
+		name           = "OctopusTestMachineHealthCheck"
+		description    = "Environment for testing Octopus Machines"
+		use_guided_failure = "false"
+	}
+
+	resource "octopusdeploy_machine" "foomac" {
+		name                            = "%s"
+		environments                    = ["${octopusdeploy_environment.tf_test_env.id}"]
+		isdisabled                      = false
+		machinepolicy                   = "${data.octopusdeploy_machinepolicy.default.id}"
+		roles                           = ["Prod"]
+		tenanteddeploymentparticipation = "Untenanted"
+		wait_for_health_check           = true
+
+		endpoint {
+		  communicationstyle = "TentaclePassive"
+		  thumbprint         = "81D0FF8B76FC"
+		  uri                = "https://octo-terra-test-unreachable:10933"
+		}
+
+		timeouts {
+		  create = "1m"
+		}
+	  }
+		`, machineName,
+	)
+}
+
 func testMachineBasic(machineName string) string {
 	config := fmt.Sprintf(`
 	data "octopusdeploy_machinepolicy" "default" {
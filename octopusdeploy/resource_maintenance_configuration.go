@@ -0,0 +1,60 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// maintenanceConfigurationID is the ID of the maintenance mode setting, of which there is only one per Octopus server
+const maintenanceConfigurationID = "maintenance"
+
+func resourceMaintenanceConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceMaintenanceConfigurationUpdate,
+		Read:   resourceMaintenanceConfigurationRead,
+		Update: resourceMaintenanceConfigurationUpdate,
+		Delete: resourceServerConfigurationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"is_in_maintenance_mode": &schema.Schema{
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Put Octopus in maintenance mode, where only administrators can make changes or deploy.",
+			},
+		},
+	}
+}
+
+func resourceMaintenanceConfigurationRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	maintenance, err := client.Configuration.GetMaintenance()
+
+	if err != nil {
+		return fmt.Errorf("error reading maintenance configuration: %s", err.Error())
+	}
+
+	log.Printf("[DEBUG] maintenance configuration: %v", m)
+	d.Set("is_in_maintenance_mode", maintenance.IsInMaintenanceMode)
+
+	return nil
+}
+
+func resourceMaintenanceConfigurationUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	maintenance := &octopusdeploy.MaintenanceConfiguration{
+		IsInMaintenanceMode: d.Get("is_in_maintenance_mode").(bool),
+	}
+
+	if _, err := client.Configuration.UpdateMaintenance(maintenance); err != nil {
+		return fmt.Errorf("error updating maintenance configuration: %s", err.Error())
+	}
+
+	d.SetId(maintenanceConfigurationID)
+
+	return resourceMaintenanceConfigurationRead(d, m)
+}
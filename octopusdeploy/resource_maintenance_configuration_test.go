@@ -0,0 +1,65 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccOctopusDeployMaintenanceConfiguration(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_maintenance_configuration.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMaintenanceConfiguration(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployMaintenanceMode(true),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "is_in_maintenance_mode", "true"),
+				),
+			},
+			// leave maintenance mode so the other tests can run
+			{
+				Config: testAccMaintenanceConfiguration(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployMaintenanceMode(false),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "is_in_maintenance_mode", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMaintenanceConfiguration(isInMaintenanceMode bool) string {
+	return fmt.Sprintf(`
+		resource "octopusdeploy_maintenance_configuration" "foo" {
+			is_in_maintenance_mode = %t
+		}
+		`,
+		isInMaintenanceMode,
+	)
+}
+
+func testAccCheckOctopusDeployMaintenanceMode(isInMaintenanceMode bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*octopusdeploy.Client)
+
+		maintenance, err := client.Configuration.GetMaintenance()
+
+		if err != nil {
+			return fmt.Errorf("Received an error retrieving maintenance configuration %s", err)
+		}
+
+		if maintenance.IsInMaintenanceMode != isInMaintenanceMode {
+			return fmt.Errorf("Expected maintenance mode to be %t but it is %t", isInMaintenanceMode, maintenance.IsInMaintenanceMode)
+		}
+
+		return nil
+	}
+}
@@ -1,17 +1,20 @@
 package octopusdeploy
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
 func resourceProject() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Create: resourceProjectCreate,
 		Read:   resourceProjectRead,
 		Update: resourceProjectUpdate,
@@ -57,12 +60,195 @@ func resourceProject() *schema.Resource {
 					"None",
 				}),
 			},
-			"deployment_step_windows_service": getDeploymentStepWindowsServiceSchema(),
-			"deployment_step_iis_website":     getDeploymentStepIISWebsiteSchema(),
-			"deployment_step_inline_script":   getDeploymentStepInlineScriptSchema(),
-			"deployment_step_package_script":  getDeploymentStepPackageScriptSchema(),
-			"deployment_step_package_extract": 
-			
+			"allow_deployments_to_no_targets": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow deployments to be created when there are no deployment targets.",
+			},
+			"auto_create_release": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Automatically create a release when a package is pushed to the built-in feed.",
+			},
+			"default_to_skip_if_already_installed": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip any package step where the same package version is already installed on the deployment target.",
+			},
+			"discrete_channel_release": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Treat releases of different channels to the same environment as a separate deployment dimension.",
+			},
+			"is_disabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Prevent releases being created or deployed for this project.",
+			},
+			"tenanted_deployment_mode": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Untenanted",
+				Description: "Whether deployments of this project are tenanted, untenanted or either.",
+				ValidateFunc: validateValueFunc([]string{
+					"Untenanted",
+					"TenantedOrUntenanted",
+					"Tenanted",
+				}),
+			},
+			"included_library_variable_set_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The IDs of the library variable sets included in this project.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"release_creation_strategy": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"channel_id": {
+							Type:        schema.TypeString,
+							Description: "The channel releases are automatically created in.",
+							Optional:    true,
+						},
+						"release_creation_package_step_id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the step whose package triggers automatic release creation.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"versioning_strategy": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"template": {
+							Type:        schema.TypeString,
+							Description: "The template used to generate release version numbers. Cannot be combined with donor_package_step_id.",
+							Optional:    true,
+						},
+						"donor_package_step_id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the step whose package version is used as the release version. Cannot be combined with template.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"auto_deploy_release_overrides": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The releases Octopus pins for automatic deployment when an older release is deployed by hand. Octopus maintains these itself, so they are read only.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"environment_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"release_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tenant_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"template": getProjectTemplateSchema(),
+		},
+	}
+
+	addDeploymentStepsSchema(resource.Schema)
+
+	return resource
+}
+
+// getProjectTemplateSchema returns schema for project variable templates, which prompt for a value per tenant
+func getProjectTemplateSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeString,
+					Description: "The ID of the template. Kept stable across updates so tenant values are not orphaned.",
+					Computed:    true,
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Description: "The name of the variable the template creates.",
+					Required:    true,
+				},
+				"label": {
+					Type:        schema.TypeString,
+					Description: "The label shown when prompting for a value.",
+					Optional:    true,
+				},
+				"help_text": {
+					Type:        schema.TypeString,
+					Description: "The help text shown when prompting for a value.",
+					Optional:    true,
+				},
+				"default_value": {
+					Type:        schema.TypeString,
+					Description: "The default value of the variable.",
+					Optional:    true,
+				},
+				"default_sensitive_value": {
+					Type:        schema.TypeString,
+					Description: "The default value of the variable when control_type is Sensitive.",
+					Optional:    true,
+					Sensitive:   true,
+				},
+				"control_type": {
+					Type:        schema.TypeString,
+					Description: "The type of control used to prompt for a value.",
+					Optional:    true,
+					Default:     "SingleLineText",
+					ValidateFunc: validateValueFunc([]string{
+						"SingleLineText",
+						"MultiLineText",
+						"Checkbox",
+						"Select",
+						"Sensitive",
+						"Certificate",
+						"AmazonWebServicesAccount",
+					}),
+				},
+				"select_option": {
+					Type:        schema.TypeList,
+					Description: "The options offered by a Select control.",
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"value": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"display_name": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -87,54 +273,152 @@ func addFeedAndPackageDeploymentStepSchema(schemaToAddToo interface{}) *schema.R
 	return schemaResource
 }
 
-func addFeedAndPackageExtractStepSchema(schemaToAddToo interface{}) *schema.Resource {
+// addConfigurationTransformDeploymentStepSchema adds schemas related to modifying configuration files
+func addConfigurationTransformDeploymentStepSchema(schemaToAddToo interface{}) *schema.Resource {
 	schemaResource := schemaToAddToo.(*schema.Resource)
 
-	schemaResource.Schema["run_on_server"] = &schema.Schema{
+	schemaResource.Schema["configuration_transforms"] = &schema.Schema{
 		Type:        schema.TypeBool,
-		Description: "Whether to run the step on the Octopus Server or not",
+		Description: "Enables XML configuration transformations.",
 		Optional:    true,
-		Default:     "feeds-builtin",
+		Default:     true,
 	}
 
-	schemaResource.Schema["package"] = &schema.Schema{
+	schemaResource.Schema["configuration_variables"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Enables replacing appSettings and connectionString entries in any .config file.",
+		Optional:    true,
+		Default:     true,
+	}
+
+	schemaResource.Schema["json_file_variable_replacement"] = &schema.Schema{
 		Type:        schema.TypeString,
-		Description: "ID / Name of the package to be deployed.",
-		Required:    true,
+		Optional:    true,
+		Description: "A comma-separated list of file names to replace settings in, relative to the package contents.",
 	}
 
 	return schemaResource
 }
 
-// addConfigurationTransformDeploymentStepSchema adds schemas related to modifying configuration files
-func addConfigurationTransformDeploymentStepSchema(schemaToAddToo interface{}) *schema.Resource {
+// addCustomInstallationDirectoryDeploymentStepSchema adds schema for Octopus Deploy Steps that can install a package to a custom directory
+func addCustomInstallationDirectoryDeploymentStepSchema(schemaToAddToo interface{}) *schema.Resource {
 	schemaResource := schemaToAddToo.(*schema.Resource)
 
-	schemaResource.Schema["configuration_transforms"] = &schema.Schema{
-		Type:        schema.TypeBool,
-		Description: "Enables XML configuration transformations.",
+	schemaResource.Schema["custom_installation_directory"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The directory the package is installed to, instead of the default Octopus Tentacle application directory.",
 		Optional:    true,
-		Default:     true,
 	}
 
-	schemaResource.Schema["configuration_variables"] = &schema.Schema{
+	schemaResource.Schema["purge_custom_installation_directory"] = &schema.Schema{
 		Type:        schema.TypeBool,
-		Description: "Enables replacing appSettings and connectionString entries in any .config file.",
+		Description: "Whether to delete the contents of the custom installation directory before installing the package.",
 		Optional:    true,
-		Default:     true,
+		Default:     false,
 	}
 
-	schemaResource.Schema["json_file_variable_replacement"] = &schema.Schema{
+	return schemaResource
+}
+
+// addSubstituteInFilesDeploymentStepSchema adds schema for replacing #{Variable} tokens in files of a package
+func addSubstituteInFilesDeploymentStepSchema(schemaToAddToo interface{}) *schema.Resource {
+	schemaResource := schemaToAddToo.(*schema.Resource)
+
+	schemaResource.Schema["substitute_in_files"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Replaces #{Variable} tokens in files of the package with variable values.",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"target_files": {
+					Type:        schema.TypeList,
+					Description: "The files to perform substitution on, relative to the package contents. Wildcards are supported.",
+					Required:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"output_encoding": {
+					Type:        schema.TypeString,
+					Description: "The encoding the files are written in after substitution, e.g. utf-8. Each file keeps its own encoding when empty.",
+					Optional:    true,
+				},
+				"warn_if_no_match": {
+					Type:        schema.TypeBool,
+					Description: "Whether a warning is logged when a target file pattern matches no files.",
+					Optional:    true,
+					Default:     true,
+				},
+			},
+		},
+	}
+
+	return schemaResource
+}
+
+// addStructuredVariableReplacementDeploymentStepSchema adds schema for replacing values in JSON, YAML, XML and Java properties files
+func addStructuredVariableReplacementDeploymentStepSchema(schemaToAddToo interface{}) *schema.Resource {
+	schemaResource := schemaToAddToo.(*schema.Resource)
+
+	schemaResource.Schema["structured_variable_replacement"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Replaces values in JSON, YAML, XML and Java properties files with variables whose names match the path of the value.",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"target_files": {
+					Type:        schema.TypeList,
+					Description: "The files to replace values in, relative to the package contents. Wildcards are supported.",
+					Required:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+
+	return schemaResource
+}
+
+// addCustomScriptsDeploymentStepSchema adds schema for scripts run before, during and after a package is deployed
+func addCustomScriptsDeploymentStepSchema(schemaToAddToo interface{}) *schema.Resource {
+	schemaResource := schemaToAddToo.(*schema.Resource)
+
+	schemaResource.Schema["custom_scripts_syntax"] = &schema.Schema{
 		Type:        schema.TypeString,
+		Description: "The scripting language of the pre-deploy, deploy and post-deploy scripts.",
 		Optional:    true,
-		Description: "A comma-separated list of file names to replace settings in, relative to the package contents.",
+		Default:     "PowerShell",
+		ValidateFunc: validateValueFunc([]string{
+			"PowerShell",
+			"CSharp",
+			"Bash",
+			"FSharp",
+		}),
+	}
+
+	for _, stage := range customScriptStages {
+		schemaResource.Schema[stage.attribute] = &schema.Schema{
+			Type:        schema.TypeString,
+			Description: fmt.Sprintf("An inline script run %s. Cannot be set along with %s_file_name.", stage.description, stage.attribute),
+			Optional:    true,
+		}
+
+		schemaResource.Schema[stage.attribute+"_file_name"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Description: fmt.Sprintf("The path of a script file in the package, relative to the package contents, run %s. Cannot be set along with %s.", stage.description, stage.attribute),
+			Optional:    true,
+		}
 	}
 
 	return schemaResource
 }
 
 // addStandardDeploymentStepSchema adds the common schema for Octopus Deploy Steps
-func addStandardDeploymentStepSchema(schemaToAddToo interface{}, requireRole bool) *schema.Resource {
+func addStandardDeploymentStepSchema(schemaToAddToo interface{}) *schema.Resource {
 	schemaResource := schemaToAddToo.(*schema.Resource)
 	schemaResource.Schema["step_condition"] = &schema.Schema{
 		Type:        schema.TypeString,
@@ -149,6 +433,24 @@ func addStandardDeploymentStepSchema(schemaToAddToo interface{}, requireRole boo
 		Default: "success",
 	}
 
+	schemaResource.Schema["condition_expression"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The variable expression deciding whether the step runs. Required when step_condition is variable.",
+		Optional:    true,
+	}
+
+	schemaResource.Schema["package_requirement"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Whether the step runs before or after packages are acquired, or lets Octopus decide.",
+		Optional:    true,
+		Default:     "LetOctopusDecide",
+		ValidateFunc: validateValueFunc([]string{
+			"LetOctopusDecide",
+			"BeforePackageAcquisition",
+			"AfterPackageAcquisition",
+		}),
+	}
+
 	schemaResource.Schema["step_name"] = &schema.Schema{
 		Type:        schema.TypeString,
 		Description: "The name of the deployment step.",
@@ -166,15 +468,77 @@ func addStandardDeploymentStepSchema(schemaToAddToo interface{}, requireRole boo
 		}),
 	}
 
+	// required for step types running on deployment targets, which is validated when building the step as child steps
+	// use the target roles of their parent step
 	schemaResource.Schema["target_roles"] = &schema.Schema{
 		Type:     schema.TypeList,
-		Required: requireRole,
-		Optional: !requireRole,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+
+	schemaResource.Schema["environments"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The IDs of the environments the step runs in. Runs in all environments when empty.",
+		Optional:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+
+	schemaResource.Schema["excluded_environments"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The IDs of the environments the step is skipped in.",
+		Optional:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+
+	schemaResource.Schema["channels"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The IDs of the channels the step runs for. Runs for all channels when empty.",
+		Optional:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+
+	schemaResource.Schema["tenant_tags"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The canonical names of the tenant tags, e.g. Region/Asia, of the tenants the step runs for. Runs for all tenants when empty.",
+		Optional:    true,
 		Elem: &schema.Schema{
 			Type: schema.TypeString,
 		},
 	}
 
+	schemaResource.Schema["disabled"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Whether the step is disabled, so it is skipped in all deployments.",
+		Optional:    true,
+		Default:     false,
+	}
+
+	schemaResource.Schema["window_size"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Makes the step a rolling deployment, which runs on this many deployment targets at a time. Can be a variable expression.",
+		Optional:    true,
+	}
+
+	schemaResource.Schema["parent_step"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The name of the step this step runs as a child of. Child steps run one after another on each deployment target of their parent step.",
+		Optional:    true,
+	}
+
+	schemaResource.Schema["order"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Description: "The position the step runs in, starting at 1. For child steps this is the position amongst the child steps of the parent step. Either all or none of the steps of a project must set this, and all must set it when steps of more than one type are declared.",
+		Optional:    true,
+	}
+
 	return schemaResource
 }
 
@@ -185,7 +549,7 @@ func addIISApplicationPoolSchema(schemaToAddToo interface{}) *schema.Resource {
 	schemaResource.Schema["application_pool_name"] = &schema.Schema{
 		Type:        schema.TypeString,
 		Description: "Name of the application pool in IIS to create or reconfigure.",
-		Required:    true,
+		Optional:    true,
 	}
 
 	schemaResource.Schema["application_pool_framework"] = &schema.Schema{
@@ -247,7 +611,7 @@ func getDeploymentStepInlineScriptSchema() *schema.Schema {
 		},
 	}
 
-	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem, false)
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
 
 	return schemaToReturn
 }
@@ -279,463 +643,3283 @@ func getDeploymentStepPackageScriptSchema() *schema.Schema {
 	}
 
 	schemaToReturn.Elem = addFeedAndPackageDeploymentStepSchema(schemaToReturn.Elem)
-	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem, false)
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addSubstituteInFilesDeploymentStepSchema(schemaToReturn.Elem)
 
 	return schemaToReturn
 }
 
+// getDeploymentStepPackageExtractSchema returns schema for a deploy a package step, which installs a package on the deployment targets
 func getDeploymentStepPackageExtractSchema() *schema.Schema {
-	
 	schemaToReturn := &schema.Schema{
 		Type:     schema.TypeList,
 		Optional: true,
 		Elem: &schema.Resource{
-			Schema: map[string]*schema.Schema{
-
-				"run_on_server": {
-					Type:        schema.TypeBool,
-					Description: "Whether the script runs on the server (true) or target (false)",
-					Optional:    true,
-					Default:     false,
-				},
-				"substitute_targets": {
-					Type:		schema.TypeString,
-					Description: "Target files to perform substiution on"
-					Optional: true,
-					Default: "*.json"
-				}
-
-
-			},
+			Schema: map[string]*schema.Schema{},
 		},
 	}
-	
+
 	schemaToReturn.Elem = addFeedAndPackageDeploymentStepSchema(schemaToReturn.Elem)
-	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem, false)
-	schemaToReturn.Elem = addFeedAndPackageExtractStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addConfigurationTransformDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addCustomInstallationDirectoryDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addSubstituteInFilesDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addStructuredVariableReplacementDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addCustomScriptsDeploymentStepSchema(schemaToReturn.Elem)
 
 	return schemaToReturn
 }
 
-// getDeploymentStepIISWebsiteSchema returns schema for an IIS deployment step
-func getDeploymentStepIISWebsiteSchema() *schema.Schema {
+// getDeploymentStepManualInterventionSchema returns schema for a manual intervention step, which pauses a deployment until a user approves it
+func getDeploymentStepManualInterventionSchema() *schema.Schema {
 	schemaToReturn := &schema.Schema{
 		Type:     schema.TypeList,
 		Optional: true,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
-				"anonymous_authentication": {
-					Type:        schema.TypeBool,
-					Optional:    true,
-					Description: "Whether IIS should allow anonymous authentication.",
-					Default:     false,
-				},
-				"basic_authentication": {
-					Type:        schema.TypeBool,
-					Optional:    true,
-					Description: "Whether IIS should allow basic authentication with a 401 challenge.",
-					Default:     false,
-				},
-				"website_name": {
+				"instructions": {
 					Type:        schema.TypeString,
-					Description: "The name of the Website to be created",
+					Description: "The instructions shown to the user who needs to intervene.",
 					Required:    true,
 				},
-				"windows_authentication": {
+				"responsible_teams": {
+					Type:        schema.TypeList,
+					Description: "The IDs of the teams who can intervene. Any user who can deploy can intervene when empty.",
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"block_deployments": {
 					Type:        schema.TypeBool,
+					Description: "Whether other deployments are blocked while waiting for the intervention.",
 					Optional:    true,
-					Description: "Whether IIS should allow integrated Windows authentication with a 401 challenge.",
-					Default:     true,
+					Default:     false,
 				},
 			},
 		},
 	}
 
-	schemaToReturn.Elem = addConfigurationTransformDeploymentStepSchema(schemaToReturn.Elem)
-	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem, true)
-	schemaToReturn.Elem = addFeedAndPackageDeploymentStepSchema(schemaToReturn.Elem)
-	schemaToReturn.Elem = addIISApplicationPoolSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
 
 	return schemaToReturn
 }
 
-// getDeploymentStepWindowsServiceSchema returns schema for a Windows Service deployment step
-func getDeploymentStepWindowsServiceSchema() *schema.Schema {
+// getKubernetesNamespaceSchema returns schema for the Kubernetes namespace a step deploys to
+func getKubernetesNamespaceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The Kubernetes namespace to deploy to. Uses the namespace of the deployment target when empty.",
+		Optional:    true,
+	}
+}
+
+// getDeploymentStepKubernetesContainersSchema returns schema for a step deploying a container to Kubernetes as a deployment and service
+func getDeploymentStepKubernetesContainersSchema() *schema.Schema {
 	schemaToReturn := &schema.Schema{
 		Type:     schema.TypeList,
 		Optional: true,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
-				"executable_path": {
-					Type:     schema.TypeString,
-					Required: true,
+				"deployment_name": {
+					Type:        schema.TypeString,
+					Description: "The name of the Kubernetes deployment.",
+					Required:    true,
 				},
-				"service_account": {
-					Type:     schema.TypeString,
-					Optional: true,
-					Default:  "LocalSystem",
+				"namespace": getKubernetesNamespaceSchema(),
+				"replicas": {
+					Type:        schema.TypeInt,
+					Description: "The number of pods to run.",
+					Optional:    true,
+					Default:     1,
 				},
-				"service_name": {
-					Type:     schema.TypeString,
-					Required: true,
+				"deployment_style": {
+					Type:        schema.TypeString,
+					Description: "How pods are replaced when the deployment is updated.",
+					Optional:    true,
+					Default:     "RollingUpdate",
+					ValidateFunc: validateValueFunc([]string{
+						"RollingUpdate",
+						"Recreate",
+						"BlueGreen",
+					}),
 				},
-				"service_start_mode": {
-					Type:     schema.TypeString,
-					Optional: true,
-					Default:  "auto",
+				"container_name": {
+					Type:        schema.TypeString,
+					Description: "The name of the container. The container image is the package of the step.",
+					Required:    true,
+				},
+				"container_port": {
+					Type:        schema.TypeList,
+					Description: "A port exposed by the container.",
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"port": {
+								Type:     schema.TypeInt,
+								Required: true,
+							},
+							"protocol": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								Default:      "TCP",
+								ValidateFunc: validateValueFunc([]string{"TCP", "UDP"}),
+							},
+						},
+					},
+				},
+				"environment_variables": {
+					Type:        schema.TypeMap,
+					Description: "Environment variables passed to the container.",
+					Optional:    true,
+				},
+				"service_name": {
+					Type:        schema.TypeString,
+					Description: "The name of a Kubernetes service exposing the deployment. No service is created when empty.",
+					Optional:    true,
+				},
+				"service_type": {
+					Type:        schema.TypeString,
+					Description: "The type of the Kubernetes service.",
+					Optional:    true,
+					Default:     "ClusterIP",
 					ValidateFunc: validateValueFunc([]string{
-						"auto",
-						"delayed-auto",
-						"demand",
-						"unchanged",
+						"ClusterIP",
+						"NodePort",
+						"LoadBalancer",
+					}),
+				},
+				"service_port": {
+					Type:        schema.TypeList,
+					Description: "A port exposed by the Kubernetes service.",
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"port": {
+								Type:     schema.TypeInt,
+								Required: true,
+							},
+							"target_port": {
+								Type:        schema.TypeString,
+								Description: "The name or number of the container port traffic is sent to.",
+								Required:    true,
+							},
+							"protocol": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								Default:      "TCP",
+								ValidateFunc: validateValueFunc([]string{"TCP", "UDP"}),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	schemaToReturn.Elem = addFeedAndPackageDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+
+	return schemaToReturn
+}
+
+// getDeploymentStepKubernetesRawYamlSchema returns schema for a step applying Kubernetes resources from YAML
+func getDeploymentStepKubernetesRawYamlSchema() *schema.Schema {
+	schemaToReturn := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"yaml": {
+					Type:        schema.TypeString,
+					Description: "The YAML of the Kubernetes resources to apply.",
+					Required:    true,
+				},
+				"namespace": getKubernetesNamespaceSchema(),
+			},
+		},
+	}
+
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+
+	return schemaToReturn
+}
+
+// getDeploymentStepHelmChartUpgradeSchema returns schema for a step upgrading a Helm release
+func getDeploymentStepHelmChartUpgradeSchema() *schema.Schema {
+	schemaToReturn := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"release_name": {
+					Type:        schema.TypeString,
+					Description: "The name of the Helm release.",
+					Required:    true,
+				},
+				"namespace": getKubernetesNamespaceSchema(),
+				"reset_values": {
+					Type:        schema.TypeBool,
+					Description: "Whether the values of the previous release are reset rather than reused.",
+					Optional:    true,
+					Default:     true,
+				},
+				"values_files": {
+					Type:        schema.TypeList,
+					Description: "Values files in the chart package, relative to the package contents.",
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"values_yaml": {
+					Type:        schema.TypeString,
+					Description: "Values in YAML, which take precedence over the values files.",
+					Optional:    true,
+				},
+				"key_values": {
+					Type:        schema.TypeMap,
+					Description: "Individual values, which take precedence over the values files and YAML.",
+					Optional:    true,
+				},
+				"additional_args": {
+					Type:        schema.TypeString,
+					Description: "Additional arguments passed to helm upgrade.",
+					Optional:    true,
+				},
+			},
+		},
+	}
+
+	schemaToReturn.Elem = addFeedAndPackageDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+
+	return schemaToReturn
+}
+
+// getDeploymentStepKubectlScriptSchema returns schema for a step running a script with kubectl configured for the deployment target
+func getDeploymentStepKubectlScriptSchema() *schema.Schema {
+	schemaToReturn := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"script_type": {
+					Type:        schema.TypeString,
+					Description: "The scripting language of the deployment step.",
+					Required:    true,
+					ValidateFunc: validateValueFunc([]string{
+						"PowerShell",
+						"CSharp",
+						"Bash",
+						"FSharp",
+					}),
+				},
+				"script_body": {
+					Type:        schema.TypeString,
+					Description: "The script body.",
+					Required:    true,
+				},
+			},
+		},
+	}
+
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+
+	return schemaToReturn
+}
+
+// addAwsAccountDeploymentStepSchema adds schema for Octopus Deploy Steps which run against AWS
+func addAwsAccountDeploymentStepSchema(schemaToAddToo interface{}) *schema.Resource {
+	schemaResource := schemaToAddToo.(*schema.Resource)
+
+	schemaResource.Schema["aws_account_variable"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The name of the AWS account variable used to authenticate.",
+		Optional:    true,
+	}
+
+	schemaResource.Schema["use_instance_role"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Whether to authenticate with the IAM role of the EC2 instance running the step, instead of an account.",
+		Optional:    true,
+		Default:     false,
+	}
+
+	schemaResource.Schema["region"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The AWS region the step runs against, e.g. us-east-1.",
+		Required:    true,
+	}
+
+	return schemaResource
+}
+
+// addAzureAccountDeploymentStepSchema adds schema for Octopus Deploy Steps which run against Azure
+func addAzureAccountDeploymentStepSchema(schemaToAddToo interface{}) *schema.Resource {
+	schemaResource := schemaToAddToo.(*schema.Resource)
+
+	schemaResource.Schema["azure_account_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The ID of the Azure account used to authenticate.",
+		Required:    true,
+	}
+
+	return schemaResource
+}
+
+// getDeploymentStepAwsCloudFormationSchema returns schema for a step deploying an AWS CloudFormation template
+func getDeploymentStepAwsCloudFormationSchema() *schema.Schema {
+	schemaToReturn := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"stack_name": {
+					Type:        schema.TypeString,
+					Description: "The name of the CloudFormation stack.",
+					Required:    true,
+				},
+				"template_body": {
+					Type:        schema.TypeString,
+					Description: "The CloudFormation template, in JSON or YAML.",
+					Required:    true,
+				},
+				"parameters": {
+					Type:        schema.TypeMap,
+					Description: "The values of the template parameters.",
+					Optional:    true,
+				},
+				"capabilities": {
+					Type:        schema.TypeList,
+					Description: "The capabilities the stack is allowed to use.",
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+						ValidateFunc: validateValueFunc([]string{
+							"CAPABILITY_IAM",
+							"CAPABILITY_NAMED_IAM",
+							"CAPABILITY_AUTO_EXPAND",
+						}),
+					},
+				},
+				"disable_rollback": {
+					Type:        schema.TypeBool,
+					Description: "Whether a stack that fails to create is kept rather than rolled back.",
+					Optional:    true,
+					Default:     false,
+				},
+				"wait_for_completion": {
+					Type:        schema.TypeBool,
+					Description: "Whether the step waits for the stack to finish creating or updating.",
+					Optional:    true,
+					Default:     true,
+				},
+			},
+		},
+	}
+
+	schemaToReturn.Elem = addAwsAccountDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+
+	return schemaToReturn
+}
+
+// getDeploymentStepAwsS3UploadSchema returns schema for a step uploading a package to an AWS S3 bucket
+func getDeploymentStepAwsS3UploadSchema() *schema.Schema {
+	schemaToReturn := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"bucket_name": {
+					Type:        schema.TypeString,
+					Description: "The name of the S3 bucket.",
+					Required:    true,
+				},
+				"bucket_key": {
+					Type:        schema.TypeString,
+					Description: "The key the package is uploaded to. Uses the file name of the package when empty.",
+					Optional:    true,
+				},
+				"canned_acl": {
+					Type:        schema.TypeString,
+					Description: "The canned ACL applied to the uploaded package.",
+					Optional:    true,
+					Default:     "private",
+					ValidateFunc: validateValueFunc([]string{
+						"private",
+						"public-read",
+						"public-read-write",
+						"authenticated-read",
+						"bucket-owner-read",
+						"bucket-owner-full-control",
+					}),
+				},
+			},
+		},
+	}
+
+	schemaToReturn.Elem = addFeedAndPackageDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addAwsAccountDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+
+	return schemaToReturn
+}
+
+// getDeploymentStepAwsCliScriptSchema returns schema for a step running a script with the AWS CLI authenticated
+func getDeploymentStepAwsCliScriptSchema() *schema.Schema {
+	schemaToReturn := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"script_type": {
+					Type:        schema.TypeString,
+					Description: "The scripting language of the deployment step.",
+					Required:    true,
+					ValidateFunc: validateValueFunc([]string{
+						"PowerShell",
+						"CSharp",
+						"Bash",
+						"FSharp",
 					}),
 				},
+				"script_body": {
+					Type:        schema.TypeString,
+					Description: "The script body.",
+					Required:    true,
+				},
+			},
+		},
+	}
+
+	schemaToReturn.Elem = addAwsAccountDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+
+	return schemaToReturn
+}
+
+// getDeploymentStepAzureAppServiceSchema returns schema for a step deploying a package to an Azure App Service
+func getDeploymentStepAzureAppServiceSchema() *schema.Schema {
+	schemaToReturn := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"resource_group_name": {
+					Type:        schema.TypeString,
+					Description: "The name of the resource group the App Service is in.",
+					Required:    true,
+				},
+				"web_app_name": {
+					Type:        schema.TypeString,
+					Description: "The name of the App Service.",
+					Required:    true,
+				},
+				"deployment_slot": {
+					Type:        schema.TypeString,
+					Description: "The deployment slot to deploy to. Deploys to the production slot when empty.",
+					Optional:    true,
+				},
+				"remove_additional_files": {
+					Type:        schema.TypeBool,
+					Description: "Whether files in the App Service which are not in the package are deleted.",
+					Optional:    true,
+					Default:     false,
+				},
 			},
 		},
 	}
 
-	schemaToReturn.Elem = addFeedAndPackageDeploymentStepSchema(schemaToReturn.Elem)
-	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem, true)
-	schemaToReturn.Elem = addConfigurationTransformDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addFeedAndPackageDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addAzureAccountDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addCustomScriptsDeploymentStepSchema(schemaToReturn.Elem)
+
+	return schemaToReturn
+}
+
+// getDeploymentStepAzureResourceGroupSchema returns schema for a step deploying an Azure Resource Manager template
+func getDeploymentStepAzureResourceGroupSchema() *schema.Schema {
+	schemaToReturn := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"resource_group_name": {
+					Type:        schema.TypeString,
+					Description: "The name of the resource group the template is deployed to.",
+					Required:    true,
+				},
+				"deployment_mode": {
+					Type:        schema.TypeString,
+					Description: "Whether resources in the resource group which are not in the template are kept (Incremental) or deleted (Complete).",
+					Optional:    true,
+					Default:     "Incremental",
+					ValidateFunc: validateValueFunc([]string{
+						"Incremental",
+						"Complete",
+					}),
+				},
+				"template_body": {
+					Type:        schema.TypeString,
+					Description: "The Azure Resource Manager template, in JSON.",
+					Required:    true,
+				},
+				"parameters": {
+					Type:        schema.TypeMap,
+					Description: "The values of the template parameters.",
+					Optional:    true,
+				},
+			},
+		},
+	}
+
+	schemaToReturn.Elem = addAzureAccountDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+
+	return schemaToReturn
+}
+
+// getDeploymentStepAzureScriptSchema returns schema for a step running a script with the Azure CLI and PowerShell modules authenticated
+func getDeploymentStepAzureScriptSchema() *schema.Schema {
+	schemaToReturn := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"script_type": {
+					Type:        schema.TypeString,
+					Description: "The scripting language of the deployment step.",
+					Required:    true,
+					ValidateFunc: validateValueFunc([]string{
+						"PowerShell",
+						"CSharp",
+						"Bash",
+						"FSharp",
+					}),
+				},
+				"script_body": {
+					Type:        schema.TypeString,
+					Description: "The script body.",
+					Required:    true,
+				},
+			},
+		},
+	}
+
+	schemaToReturn.Elem = addAzureAccountDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+
+	return schemaToReturn
+}
+
+// getDeploymentStepHealthCheckSchema returns schema for a health check step, which checks the deployment targets in its roles
+func getDeploymentStepHealthCheckSchema() *schema.Schema {
+	schemaToReturn := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"health_check_type": {
+					Type:        schema.TypeString,
+					Description: "Whether to run a full health check, or only check the deployment targets can be connected to.",
+					Optional:    true,
+					Default:     "FullHealthCheck",
+					ValidateFunc: validateValueFunc([]string{
+						"FullHealthCheck",
+						"ConnectionTest",
+					}),
+				},
+				"error_handling": {
+					Type:        schema.TypeString,
+					Description: "Whether unhealthy deployment targets fail the step, or are skipped with a warning.",
+					Optional:    true,
+					Default:     "TreatExceptionsAsErrors",
+					ValidateFunc: validateValueFunc([]string{
+						"TreatExceptionsAsErrors",
+						"TreatExceptionsAsWarnings",
+					}),
+				},
+				"include_new_machines": {
+					Type:        schema.TypeBool,
+					Description: "Whether deployment targets added to the roles since the deployment started are checked and included in the rest of the deployment.",
+					Optional:    true,
+					Default:     false,
+				},
+			},
+		},
+	}
+
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+
+	return schemaToReturn
+}
+
+// getDeploymentStepEmailSchema returns schema for a send an email step
+func getDeploymentStepEmailSchema() *schema.Schema {
+	schemaToReturn := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"to": {
+					Type:        schema.TypeString,
+					Description: "A comma-separated list of the email addresses to send the email to.",
+					Optional:    true,
+				},
+				"to_teams": {
+					Type:        schema.TypeList,
+					Description: "The IDs of the teams whose members the email is sent to.",
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"cc": {
+					Type:        schema.TypeString,
+					Description: "A comma-separated list of the email addresses to copy the email to.",
+					Optional:    true,
+				},
+				"cc_teams": {
+					Type:        schema.TypeList,
+					Description: "The IDs of the teams whose members the email is copied to.",
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"bcc": {
+					Type:        schema.TypeString,
+					Description: "A comma-separated list of the email addresses to blind copy the email to.",
+					Optional:    true,
+				},
+				"bcc_teams": {
+					Type:        schema.TypeList,
+					Description: "The IDs of the teams whose members the email is blind copied to.",
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"subject": {
+					Type:        schema.TypeString,
+					Description: "The subject of the email. Can contain variable expressions.",
+					Required:    true,
+				},
+				"body": {
+					Type:        schema.TypeString,
+					Description: "The body of the email. Can contain variable expressions.",
+					Required:    true,
+				},
+				"is_html": {
+					Type:        schema.TypeBool,
+					Description: "Whether the body is HTML rather than plain text.",
+					Optional:    true,
+					Default:     false,
+				},
+				"priority": {
+					Type:        schema.TypeString,
+					Description: "The priority of the email.",
+					Optional:    true,
+					Default:     "Normal",
+					ValidateFunc: validateValueFunc([]string{
+						"Low",
+						"Normal",
+						"High",
+					}),
+				},
+			},
+		},
+	}
+
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+
+	return schemaToReturn
+}
+
+// getDeploymentStepDeployReleaseSchema returns schema for a deploy a release step, which deploys a release of another project
+func getDeploymentStepDeployReleaseSchema() *schema.Schema {
+	schemaToReturn := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"project_id": {
+					Type:        schema.TypeString,
+					Description: "The ID of the project whose release is deployed. The release is chosen when a release of this project is created.",
+					Required:    true,
+				},
+				"deployment_condition": {
+					Type:        schema.TypeString,
+					Description: "Whether the release is always deployed, or only when it is not the current or an older release in the environment.",
+					Optional:    true,
+					Default:     "Always",
+					ValidateFunc: validateValueFunc([]string{
+						"Always",
+						"IfNotCurrentVersion",
+						"IfNewer",
+					}),
+				},
+				"variables": {
+					Type:        schema.TypeMap,
+					Description: "The values of prompted variables of the deployed project, by variable name.",
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+
+	return schemaToReturn
+}
+
+// getDeploymentStepIISWebsiteSchema returns schema for an IIS deployment step
+func getDeploymentStepIISWebsiteSchema() *schema.Schema {
+	schemaToReturn := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"anonymous_authentication": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Whether IIS should allow anonymous authentication.",
+					Default:     false,
+				},
+				"basic_authentication": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Whether IIS should allow basic authentication with a 401 challenge.",
+					Default:     false,
+				},
+				"binding": {
+					Type:        schema.TypeList,
+					Description: "A binding of the website. Defaults to a single HTTP binding on port 80.",
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"protocol": {
+								Type:        schema.TypeString,
+								Description: "The protocol of the binding.",
+								Optional:    true,
+								Default:     "http",
+								ValidateFunc: validateValueFunc([]string{
+									"http",
+									"https",
+								}),
+							},
+							"port": {
+								Type:        schema.TypeString,
+								Description: "The port of the binding. Can be a variable expression.",
+								Optional:    true,
+								Default:     "80",
+							},
+							"host": {
+								Type:        schema.TypeString,
+								Description: "The host name of the binding. Leave empty to respond to all host names.",
+								Optional:    true,
+							},
+							"certificate_variable": {
+								Type:        schema.TypeString,
+								Description: "The name of the certificate variable used by an HTTPS binding.",
+								Optional:    true,
+							},
+							"thumbprint": {
+								Type:        schema.TypeString,
+								Description: "The thumbprint of the certificate used by an HTTPS binding.",
+								Optional:    true,
+							},
+							"require_sni": {
+								Type:        schema.TypeBool,
+								Description: "Whether an HTTPS binding requires Server Name Indication.",
+								Optional:    true,
+								Default:     false,
+							},
+							"enabled": {
+								Type:        schema.TypeBool,
+								Description: "Whether the binding is enabled.",
+								Optional:    true,
+								Default:     true,
+							},
+						},
+					},
+				},
+				"deployment_type": {
+					Type:        schema.TypeString,
+					Description: "Whether to deploy a website, or a virtual directory or web application inside an existing website.",
+					Optional:    true,
+					Default:     "webSite",
+					ValidateFunc: validateValueFunc([]string{
+						"webSite",
+						"virtualDirectory",
+						"webApplication",
+					}),
+				},
+				"parent_website_name": {
+					Type:        schema.TypeString,
+					Description: "The name of the existing website the virtual directory or web application is created in.",
+					Optional:    true,
+				},
+				"virtual_path": {
+					Type:        schema.TypeString,
+					Description: "The path of the virtual directory or web application relative to the parent website, e.g. /api.",
+					Optional:    true,
+				},
+				"website_name": {
+					Type:        schema.TypeString,
+					Description: "The name of the Website to be created",
+					Optional:    true,
+				},
+				"windows_authentication": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Whether IIS should allow integrated Windows authentication with a 401 challenge.",
+					Default:     true,
+				},
+			},
+		},
+	}
+
+	schemaToReturn.Elem = addConfigurationTransformDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addFeedAndPackageDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addIISApplicationPoolSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addCustomScriptsDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addStructuredVariableReplacementDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addSubstituteInFilesDeploymentStepSchema(schemaToReturn.Elem)
+
+	return schemaToReturn
+}
+
+// getDeploymentStepWindowsServiceSchema returns schema for a Windows Service deployment step
+func getDeploymentStepWindowsServiceSchema() *schema.Schema {
+	schemaToReturn := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"arguments": {
+					Type:        schema.TypeString,
+					Description: "The command line arguments passed to the service when it starts.",
+					Optional:    true,
+				},
+				"custom_account_name": {
+					Type:        schema.TypeString,
+					Description: "The user name of the account the service runs under, e.g. DOMAIN\\user. Required when service_account is _CUSTOM.",
+					Optional:    true,
+				},
+				"custom_account_password": {
+					Type:        schema.TypeString,
+					Description: "The password of the account the service runs under.",
+					Optional:    true,
+					Sensitive:   true,
+				},
+				"executable_path": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"service_account": {
+					Type:        schema.TypeString,
+					Description: "The account the service runs under. Use _CUSTOM to run it under custom_account_name.",
+					Optional:    true,
+					Default:     "LocalSystem",
+				},
+				"service_dependencies": {
+					Type:        schema.TypeList,
+					Description: "The names of the services the service depends on.",
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"service_description": {
+					Type:        schema.TypeString,
+					Description: "The description of the service.",
+					Optional:    true,
+				},
+				"service_desired_status": {
+					Type:        schema.TypeString,
+					Description: "The state the service is left in after the deployment. Default starts the service unless its start mode is demand.",
+					Optional:    true,
+					Default:     "Default",
+					ValidateFunc: validateValueFunc([]string{
+						"Default",
+						"Started",
+						"Stopped",
+						"Unchanged",
+					}),
+				},
+				"service_display_name": {
+					Type:        schema.TypeString,
+					Description: "The display name of the service. Defaults to the service name.",
+					Optional:    true,
+				},
+				"service_name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"service_start_mode": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "auto",
+					ValidateFunc: validateValueFunc([]string{
+						"auto",
+						"delayed-auto",
+						"demand",
+						"unchanged",
+					}),
+				},
+			},
+		},
+	}
+
+	schemaToReturn.Elem = addFeedAndPackageDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addStandardDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addConfigurationTransformDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addCustomScriptsDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addCustomInstallationDirectoryDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addStructuredVariableReplacementDeploymentStepSchema(schemaToReturn.Elem)
+	schemaToReturn.Elem = addSubstituteInFilesDeploymentStepSchema(schemaToReturn.Elem)
+
+	return schemaToReturn
+}
+
+// orderedDeploymentStep is a built deployment step along with where it was declared and the order it runs in
+type orderedDeploymentStep struct {
+	attribute  string
+	index      int
+	order      int
+	parentStep string
+	step       octopusdeploy.DeploymentStep
+}
+
+// deploymentStepPosition is the position a step runs in, amongst the steps or the child steps of a parent step
+type deploymentStepPosition struct {
+	parentStep string
+	order      int
+}
+
+// checkDeploymentStepOrders checks that either all or none of the steps set an order, and that no two steps run in
+// the same position. It returns whether the steps are ordered.
+func checkDeploymentStepOrders(steps []orderedDeploymentStep) (bool, error) {
+	ordered := false
+
+	for _, step := range steps {
+		if step.order != 0 {
+			ordered = true
+			break
+		}
+	}
+
+	if !ordered {
+		return false, nil
+	}
+
+	positions := make(map[deploymentStepPosition]string)
+
+	for _, step := range steps {
+		if step.order < 1 {
+			return false, fmt.Errorf("step %s must set an order of at least 1 as other steps of the project set order", step.step.Name)
+		}
+
+		position := deploymentStepPosition{parentStep: step.parentStep, order: step.order}
+
+		if otherStepName, ok := positions[position]; ok {
+			return false, fmt.Errorf("steps %s and %s cannot both have an order of %d", otherStepName, step.step.Name, step.order)
+		}
+
+		positions[position] = step.step.Name
+	}
+
+	return true, nil
+}
+
+// checkDeploymentStepDeclarationOrder checks that the blocks of each step type are declared in the order they run, as
+// that is the order they are read back in
+func checkDeploymentStepDeclarationOrder(deploymentSteps []octopusdeploy.DeploymentStep, steps []orderedDeploymentStep) error {
+	stepsByName := make(map[string]orderedDeploymentStep)
+
+	for _, step := range steps {
+		stepsByName[step.step.Name] = step
+	}
+
+	lastIndexes := make(map[string]int)
+
+	for _, step := range deploymentSteps {
+		for _, action := range step.Actions {
+			declaredStep := stepsByName[action.Name]
+
+			if lastIndex, ok := lastIndexes[declaredStep.attribute]; ok && declaredStep.index < lastIndex {
+				return fmt.Errorf("step %s runs after a %s block declared after it, blocks of the same type must be declared in the order they run", action.Name, declaredStep.attribute)
+			}
+
+			lastIndexes[declaredStep.attribute] = declaredStep.index
+		}
+	}
+
+	return nil
+}
+
+// checkChildDeploymentStepSettings checks a child step has the same step settings as its parent step. Only the action
+// of a child step is added to the parent step, so its step settings would otherwise be silently dropped.
+func checkChildDeploymentStepSettings(childStep octopusdeploy.DeploymentStep, parentStep octopusdeploy.DeploymentStep) error {
+	settings := []struct {
+		attribute   string
+		childValue  string
+		parentValue string
+	}{
+		{"step_condition", strings.ToLower(childStep.Condition), strings.ToLower(parentStep.Condition)},
+		{"condition_expression", childStep.Properties["Octopus.Action.ConditionVariableExpression"], parentStep.Properties["Octopus.Action.ConditionVariableExpression"]},
+		{"package_requirement", childStep.PackageRequirement, parentStep.PackageRequirement},
+		{"step_start_trigger", childStep.StartTrigger, parentStep.StartTrigger},
+	}
+
+	for _, setting := range settings {
+		if setting.childValue != setting.parentValue {
+			return fmt.Errorf("step %s must have the same %s as its parent step %s (%q rather than %q), as child steps use the step settings of their parent step", childStep.Name, setting.attribute, parentStep.Name, setting.parentValue, setting.childValue)
+		}
+	}
+
+	return nil
+}
+
+// buildDeploymentSteps builds the steps of a process from the deployment_step_* attributes. It is used for both the
+// deployment process of a project and the process of a runbook.
+func buildDeploymentSteps(d *schema.ResourceData) ([]octopusdeploy.DeploymentStep, error) {
+	var deploymentSteps []octopusdeploy.DeploymentStep
+	var steps []orderedDeploymentStep
+	var childSteps []orderedDeploymentStep
+
+	for _, stepType := range deploymentStepTypes {
+		for i, raw := range d.Get(stepType.attribute).([]interface{}) {
+			localStep := raw.(map[string]interface{})
+
+			deploymentStep, err := stepType.build(localStep)
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, environmentID := range deploymentStep.Actions[0].Environments {
+				if validateStringInSlice(environmentID, deploymentStep.Actions[0].ExcludedEnvironments) {
+					return nil, fmt.Errorf("step %s cannot both run in and be skipped in environment %s", deploymentStep.Name, environmentID)
+				}
+			}
+
+			conditionExpression := localStep["condition_expression"].(string)
+
+			if localStep["step_condition"].(string) == "variable" && conditionExpression == "" {
+				return nil, fmt.Errorf("step %s must set condition_expression as its step_condition is variable", deploymentStep.Name)
+			}
+
+			if localStep["step_condition"].(string) != "variable" && conditionExpression != "" {
+				return nil, fmt.Errorf("step %s can only set condition_expression when its step_condition is variable", deploymentStep.Name)
+			}
+
+			// child steps are added as actions of their parent step once all steps are built
+			if parentStepName := localStep["parent_step"].(string); parentStepName != "" {
+				if len(getSliceFromTerraformTypeList(localStep["target_roles"])) > 0 || localStep["window_size"].(string) != "" {
+					return nil, fmt.Errorf("step %s cannot set target_roles or window_size as it is a child of step %s", deploymentStep.Name, parentStepName)
+				}
+
+				childSteps = append(childSteps, orderedDeploymentStep{
+					attribute:  stepType.attribute,
+					index:      i,
+					order:      localStep["order"].(int),
+					parentStep: parentStepName,
+					step:       *deploymentStep,
+				})
+				continue
+			}
+
+			if stepType.requireRole && deploymentStep.Properties["Octopus.Action.TargetRoles"] == "" {
+				return nil, fmt.Errorf("step %s must set target_roles as it runs on deployment targets", deploymentStep.Name)
+			}
+
+			steps = append(steps, orderedDeploymentStep{
+				attribute: stepType.attribute,
+				index:     i,
+				order:     localStep["order"].(int),
+				step:      *deploymentStep,
+			})
+		}
+	}
+
+	allSteps := append(append([]orderedDeploymentStep{}, steps...), childSteps...)
+
+	ordered, err := checkDeploymentStepOrders(allSteps)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// without an order steps are added in the order of deploymentStepTypes, which only matches the order they are
+	// written in when all blocks are of one step type
+	if !ordered {
+		for _, step := range allSteps {
+			if step.attribute != allSteps[0].attribute {
+				return nil, fmt.Errorf("step %s must set order, as steps of more than one type are declared (%s and %s) and Terraform cannot tell the order they were written in otherwise", step.step.Name, allSteps[0].attribute, step.attribute)
+			}
+		}
+	}
+
+	if ordered {
+		sort.SliceStable(steps, func(i, j int) bool { return steps[i].order < steps[j].order })
+		sort.SliceStable(childSteps, func(i, j int) bool { return childSteps[i].order < childSteps[j].order })
+	}
+
+	for _, step := range steps {
+		deploymentSteps = append(deploymentSteps, step.step)
+	}
+
+	for _, childStep := range childSteps {
+		found := false
+
+		for j := range deploymentSteps {
+			if deploymentSteps[j].Name == childStep.parentStep {
+				if err := checkChildDeploymentStepSettings(childStep.step, deploymentSteps[j]); err != nil {
+					return nil, err
+				}
+
+				deploymentSteps[j].Actions = append(deploymentSteps[j].Actions, childStep.step.Actions...)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("step %s has a parent_step of %s, which is not a step of the project or is itself a child step", childStep.step.Name, childStep.parentStep)
+		}
+	}
+
+	if ordered {
+		if err := checkDeploymentStepDeclarationOrder(deploymentSteps, allSteps); err != nil {
+			return nil, err
+		}
+	}
+
+	return deploymentSteps, nil
+}
+
+// iisBinding is a binding of an IIS website, as stored in the Octopus.Action.IISWebSite.Bindings property
+type iisBinding struct {
+	Protocol            string  `json:"protocol"`
+	Port                string  `json:"port"`
+	Host                string  `json:"host"`
+	Thumbprint          *string `json:"thumbprint"`
+	CertificateVariable *string `json:"certificateVariable"`
+	RequireSni          bool    `json:"requireSni"`
+	Enabled             bool    `json:"enabled"`
+}
+
+// buildIISBindings converts the binding blocks of an IIS step into the JSON Octopus expects. When no bindings are
+// given a single HTTP binding on port 80 is returned, matching the IIS step before bindings were configurable.
+func buildIISBindings(stepName string, tfBindings []interface{}) (string, error) {
+	bindings := []iisBinding{}
+
+	for _, raw := range tfBindings {
+		tfBinding := raw.(map[string]interface{})
+
+		binding := iisBinding{
+			Protocol:   tfBinding["protocol"].(string),
+			Port:       tfBinding["port"].(string),
+			Host:       tfBinding["host"].(string),
+			RequireSni: tfBinding["require_sni"].(bool),
+			Enabled:    tfBinding["enabled"].(bool),
+		}
+
+		certificateVariable := tfBinding["certificate_variable"].(string)
+		thumbprint := tfBinding["thumbprint"].(string)
+
+		if binding.Protocol == "https" {
+			if (certificateVariable == "") == (thumbprint == "") {
+				return "", fmt.Errorf("https binding on port %s of step %s must set exactly one of certificate_variable or thumbprint", binding.Port, stepName)
+			}
+
+			if certificateVariable != "" {
+				binding.CertificateVariable = &certificateVariable
+			} else {
+				binding.Thumbprint = &thumbprint
+			}
+		} else if certificateVariable != "" || thumbprint != "" || binding.RequireSni {
+			return "", fmt.Errorf("http binding on port %s of step %s cannot set certificate_variable, thumbprint or require_sni", binding.Port, stepName)
+		}
+
+		bindings = append(bindings, binding)
+	}
+
+	if len(bindings) == 0 {
+		bindings = append(bindings, iisBinding{
+			Protocol: "http",
+			Port:     "80",
+			Enabled:  true,
+		})
+	}
+
+	bindingsJSON, err := json.Marshal(bindings)
+
+	if err != nil {
+		return "", fmt.Errorf("error building bindings of step %s: %s", stepName, err.Error())
+	}
+
+	return string(bindingsJSON), nil
+}
+
+// flattenIISBindings converts the bindings JSON of an IIS website into binding blocks. The single HTTP binding on
+// port 80 used when no bindings are given is read back as no binding blocks.
+func flattenIISBindings(bindingsJSON string) []interface{} {
+	var bindings []iisBinding
+
+	if err := json.Unmarshal([]byte(bindingsJSON), &bindings); err != nil {
+		return nil
+	}
+
+	if len(bindings) == 1 && bindings[0] == (iisBinding{Protocol: "http", Port: "80", Enabled: true}) {
+		return nil
+	}
+
+	var tfBindings []interface{}
+
+	for _, binding := range bindings {
+		tfBinding := map[string]interface{}{
+			"protocol":    binding.Protocol,
+			"port":        binding.Port,
+			"host":        binding.Host,
+			"require_sni": binding.RequireSni,
+			"enabled":     binding.Enabled,
+		}
+
+		if binding.CertificateVariable != nil {
+			tfBinding["certificate_variable"] = *binding.CertificateVariable
+		}
+
+		if binding.Thumbprint != nil {
+			tfBinding["thumbprint"] = *binding.Thumbprint
+		}
+
+		tfBindings = append(tfBindings, tfBinding)
+	}
+
+	return tfBindings
+}
+
+// customScriptSyntax is a scripting language custom scripts can be written in. Octopus stores the scripts under the
+// file extension of their language. Octopus only stores inline custom scripts, so a script file in the package is
+// run by an inline script made from packageFileScript, as custom scripts run from the extracted package.
+type customScriptSyntax struct {
+	syntax            string
+	fileExtension     string
+	packageFileScript string
+}
+
+// customScriptSyntaxes are the languages custom scripts can be written in, in the order they are read back
+var customScriptSyntaxes = []customScriptSyntax{
+	{syntax: "PowerShell", fileExtension: "ps1", packageFileScript: "& (Join-Path (Get-Location) '%s')"},
+	{syntax: "CSharp", fileExtension: "csx", packageFileScript: "#load \"%s\""},
+	{syntax: "Bash", fileExtension: "sh", packageFileScript: ". \"$(pwd)/%s\""},
+	{syntax: "FSharp", fileExtension: "fsx", packageFileScript: "#load \"%s\""},
+}
+
+// customScriptStage is a stage of a package deployment a custom script can run in
+type customScriptStage struct {
+	attribute   string
+	stage       string
+	description string
+}
+
+// customScriptStages are the stages a custom script can run in, in the order they run
+var customScriptStages = []customScriptStage{
+	{attribute: "pre_deploy_script", stage: "PreDeploy", description: "before the package is installed"},
+	{attribute: "deploy_script", stage: "Deploy", description: "after the package is installed, before it is configured"},
+	{attribute: "post_deploy_script", stage: "PostDeploy", description: "after the package is installed and configured"},
+}
+
+// getCustomScriptSyntax returns the language custom scripts of the given syntax are written in
+func getCustomScriptSyntax(syntax string) customScriptSyntax {
+	for _, scriptSyntax := range customScriptSyntaxes {
+		if scriptSyntax.syntax == syntax {
+			return scriptSyntax
+		}
+	}
+
+	return customScriptSyntaxes[0]
+}
+
+// parsePackageFileScript returns the path of the package file a custom script runs, if it is a script made from
+// packageFileScript. An inline script written exactly like one is read back as the file it runs.
+func parsePackageFileScript(scriptSyntax customScriptSyntax, script string) (string, bool) {
+	parts := strings.SplitN(scriptSyntax.packageFileScript, "%s", 2)
+
+	if !strings.HasPrefix(script, parts[0]) || !strings.HasSuffix(script, parts[1]) || len(script) <= len(parts[0])+len(parts[1]) {
+		return "", false
+	}
+
+	fileName := strings.TrimSuffix(strings.TrimPrefix(script, parts[0]), parts[1])
+
+	if strings.ContainsAny(fileName, "'\"\n") {
+		return "", false
+	}
+
+	return fileName, true
+}
+
+// addEnabledFeature adds a feature to the comma-separated list of features enabled on an action
+func addEnabledFeature(action *octopusdeploy.DeploymentAction, feature string) {
+	if action.Properties["Octopus.Action.EnabledFeatures"] == "" {
+		action.Properties["Octopus.Action.EnabledFeatures"] = feature
+		return
+	}
+
+	action.Properties["Octopus.Action.EnabledFeatures"] += "," + feature
+}
+
+// hasEnabledFeature returns whether a feature is enabled on an action
+func hasEnabledFeature(action octopusdeploy.DeploymentAction, feature string) bool {
+	return validateStringInSlice(feature, strings.Split(action.Properties["Octopus.Action.EnabledFeatures"], ","))
+}
+
+// getBoolProperty returns the value of a boolean property, which Octopus stores as "True" or "False"
+func getBoolProperty(properties map[string]string, key string) bool {
+	value, _ := strconv.ParseBool(properties[key])
+	return value
+}
+
+// getListProperty returns the value of a property holding a newline separated list
+func getListProperty(properties map[string]string, key string) []string {
+	var list []string
+
+	for _, item := range strings.Split(properties[key], "\n") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+// getSortedMapKeys returns the keys of a map in order, so properties built from a map do not change between applies
+func getSortedMapKeys(m map[string]interface{}) []string {
+	var keys []string
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// buildStandardDeploymentStep builds a deployment step with a single action from the common step schema
+func buildStandardDeploymentStep(localStep map[string]interface{}, actionType string) *octopusdeploy.DeploymentStep {
+	stepName := localStep["step_name"].(string)
+
+	deploymentStep := &octopusdeploy.DeploymentStep{
+		Name:               stepName,
+		PackageRequirement: localStep["package_requirement"].(string),
+		Condition:          localStep["step_condition"].(string),
+		StartTrigger:       localStep["step_start_trigger"].(string),
+		Properties:         map[string]string{},
+		Actions: []octopusdeploy.DeploymentAction{
+			{
+				Name:                 stepName,
+				ActionType:           actionType,
+				IsDisabled:           localStep["disabled"].(bool),
+				Environments:         getSliceFromTerraformTypeList(localStep["environments"]),
+				ExcludedEnvironments: getSliceFromTerraformTypeList(localStep["excluded_environments"]),
+				Channels:             getSliceFromTerraformTypeList(localStep["channels"]),
+				TenantTags:           getSliceFromTerraformTypeList(localStep["tenant_tags"]),
+				Properties:           map[string]string{},
+			},
+		},
+	}
+
+	if targetRoles := getSliceFromTerraformTypeList(localStep["target_roles"]); len(targetRoles) > 0 {
+		deploymentStep.Properties["Octopus.Action.TargetRoles"] = strings.Join(targetRoles, ",")
+	}
+
+	if windowSize := localStep["window_size"].(string); windowSize != "" {
+		deploymentStep.Properties["Octopus.Action.MaxParallelism"] = windowSize
+	}
+
+	if conditionExpression := localStep["condition_expression"].(string); conditionExpression != "" {
+		deploymentStep.Properties["Octopus.Action.ConditionVariableExpression"] = conditionExpression
+	}
+
+	return deploymentStep
+}
+
+// flattenStandardDeploymentStep converts a deployment step into the common step schema
+func flattenStandardDeploymentStep(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	action := step.Actions[0]
+
+	tfStep := map[string]interface{}{
+		"disabled":              action.IsDisabled,
+		"environments":          action.Environments,
+		"excluded_environments": action.ExcludedEnvironments,
+		"channels":              action.Channels,
+		"tenant_tags":           action.TenantTags,
+		"step_name":             step.Name,
+		"step_condition":        strings.ToLower(step.Condition),
+		"condition_expression":  step.Properties["Octopus.Action.ConditionVariableExpression"],
+		"package_requirement":   step.PackageRequirement,
+		"step_start_trigger":    step.StartTrigger,
+		"window_size":           step.Properties["Octopus.Action.MaxParallelism"],
+	}
+
+	if targetRoles := step.Properties["Octopus.Action.TargetRoles"]; targetRoles != "" {
+		tfStep["target_roles"] = strings.Split(targetRoles, ",")
+	}
+
+	return tfStep
+}
+
+func addFeedAndPackageDeploymentStepProperties(localStep map[string]interface{}, action *octopusdeploy.DeploymentAction) {
+	action.Properties["Octopus.Action.Package.FeedId"] = localStep["feed_id"].(string)
+	action.Properties["Octopus.Action.Package.PackageId"] = localStep["package"].(string)
+	action.Properties["Octopus.Action.Package.DownloadOnTentacle"] = "False"
+}
+
+func flattenFeedAndPackageDeploymentStepProperties(action octopusdeploy.DeploymentAction, tfStep map[string]interface{}) {
+	tfStep["feed_id"] = action.Properties["Octopus.Action.Package.FeedId"]
+	tfStep["package"] = action.Properties["Octopus.Action.Package.PackageId"]
+}
+
+func addConfigurationTransformDeploymentStepProperties(localStep map[string]interface{}, action *octopusdeploy.DeploymentAction) {
+	configurationTransforms := localStep["configuration_transforms"].(bool)
+	configurationVariables := localStep["configuration_variables"].(bool)
+	jsonFileVariableReplacement := localStep["json_file_variable_replacement"].(string)
+
+	action.Properties["Octopus.Action.Package.AutomaticallyRunConfigurationTransformationFiles"] = strconv.FormatBool(configurationTransforms)
+	action.Properties["Octopus.Action.Package.AutomaticallyUpdateAppSettingsAndConnectionStrings"] = strconv.FormatBool(configurationVariables)
+
+	if configurationTransforms {
+		addEnabledFeature(action, "Octopus.Features.ConfigurationTransforms")
+	}
+
+	if configurationVariables {
+		addEnabledFeature(action, "Octopus.Features.ConfigurationVariables")
+	}
+
+	if jsonFileVariableReplacement != "" {
+		action.Properties["Octopus.Action.Package.JsonConfigurationVariablesTargets"] = jsonFileVariableReplacement
+		action.Properties["Octopus.Action.Package.JsonConfigurationVariablesEnabled"] = "True"
+
+		addEnabledFeature(action, "Octopus.Features.JsonConfigurationVariables")
+	}
+}
+
+func flattenConfigurationTransformDeploymentStepProperties(action octopusdeploy.DeploymentAction, tfStep map[string]interface{}) {
+	tfStep["configuration_transforms"] = getBoolProperty(action.Properties, "Octopus.Action.Package.AutomaticallyRunConfigurationTransformationFiles")
+	tfStep["configuration_variables"] = getBoolProperty(action.Properties, "Octopus.Action.Package.AutomaticallyUpdateAppSettingsAndConnectionStrings")
+
+	if getBoolProperty(action.Properties, "Octopus.Action.Package.JsonConfigurationVariablesEnabled") {
+		tfStep["json_file_variable_replacement"] = action.Properties["Octopus.Action.Package.JsonConfigurationVariablesTargets"]
+	}
+}
+
+func addCustomInstallationDirectoryDeploymentStepProperties(localStep map[string]interface{}, action *octopusdeploy.DeploymentAction) {
+	customInstallationDirectory := localStep["custom_installation_directory"].(string)
+
+	if customInstallationDirectory == "" {
+		return
+	}
+
+	action.Properties["Octopus.Action.Package.CustomInstallationDirectory"] = customInstallationDirectory
+	action.Properties["Octopus.Action.Package.CustomInstallationDirectoryShouldBePurgedBeforeDeployment"] = strconv.FormatBool(localStep["purge_custom_installation_directory"].(bool))
+
+	addEnabledFeature(action, "Octopus.Features.CustomDirectory")
+}
+
+func flattenCustomInstallationDirectoryDeploymentStepProperties(action octopusdeploy.DeploymentAction, tfStep map[string]interface{}) {
+	if !hasEnabledFeature(action, "Octopus.Features.CustomDirectory") {
+		return
+	}
+
+	tfStep["custom_installation_directory"] = action.Properties["Octopus.Action.Package.CustomInstallationDirectory"]
+	tfStep["purge_custom_installation_directory"] = getBoolProperty(action.Properties, "Octopus.Action.Package.CustomInstallationDirectoryShouldBePurgedBeforeDeployment")
+}
+
+func addSubstituteInFilesDeploymentStepProperties(localStep map[string]interface{}, action *octopusdeploy.DeploymentAction) {
+	substituteInFiles := localStep["substitute_in_files"].([]interface{})
+
+	if len(substituteInFiles) == 0 {
+		return
+	}
+
+	tfSubstituteInFiles := substituteInFiles[0].(map[string]interface{})
+
+	action.Properties["Octopus.Action.SubstituteInFiles.Enabled"] = "True"
+	action.Properties["Octopus.Action.SubstituteInFiles.TargetFiles"] = strings.Join(getSliceFromTerraformTypeList(tfSubstituteInFiles["target_files"]), "\n")
+	action.Properties["Octopus.Action.SubstituteInFiles.EnableNoMatchWarning"] = strconv.FormatBool(tfSubstituteInFiles["warn_if_no_match"].(bool))
+
+	if outputEncoding := tfSubstituteInFiles["output_encoding"].(string); outputEncoding != "" {
+		action.Properties["Octopus.Action.SubstituteInFiles.OutputEncoding"] = outputEncoding
+	}
+
+	addEnabledFeature(action, "Octopus.Features.SubstituteInFiles")
+}
+
+func flattenSubstituteInFilesDeploymentStepProperties(action octopusdeploy.DeploymentAction, tfStep map[string]interface{}) {
+	if !hasEnabledFeature(action, "Octopus.Features.SubstituteInFiles") {
+		return
+	}
+
+	warnIfNoMatch := true
+
+	if enableNoMatchWarning, ok := action.Properties["Octopus.Action.SubstituteInFiles.EnableNoMatchWarning"]; ok {
+		warnIfNoMatch, _ = strconv.ParseBool(enableNoMatchWarning)
+	}
+
+	tfStep["substitute_in_files"] = []interface{}{
+		map[string]interface{}{
+			"target_files":     getListProperty(action.Properties, "Octopus.Action.SubstituteInFiles.TargetFiles"),
+			"output_encoding":  action.Properties["Octopus.Action.SubstituteInFiles.OutputEncoding"],
+			"warn_if_no_match": warnIfNoMatch,
+		},
+	}
+}
+
+func addStructuredVariableReplacementDeploymentStepProperties(localStep map[string]interface{}, deploymentStep *octopusdeploy.DeploymentStep) error {
+	action := &deploymentStep.Actions[0]
+	structuredVariableReplacement := localStep["structured_variable_replacement"].([]interface{})
+
+	if len(structuredVariableReplacement) == 0 {
+		return nil
+	}
+
+	// both store their target files in the same property, and ConflictsWith cannot be used inside a schema.TypeList
+	if jsonFileVariableReplacement, ok := localStep["json_file_variable_replacement"]; ok && jsonFileVariableReplacement.(string) != "" {
+		return fmt.Errorf("step %s cannot set both json_file_variable_replacement and structured_variable_replacement", deploymentStep.Name)
+	}
+
+	tfStructuredVariableReplacement := structuredVariableReplacement[0].(map[string]interface{})
+
+	action.Properties["Octopus.Action.StructuredConfigurationVariables.Enabled"] = "True"
+	action.Properties["Octopus.Action.Package.JsonConfigurationVariablesTargets"] = strings.Join(getSliceFromTerraformTypeList(tfStructuredVariableReplacement["target_files"]), "\n")
+
+	addEnabledFeature(action, "Octopus.Features.JsonConfigurationVariables")
+
+	return nil
+}
+
+func flattenStructuredVariableReplacementDeploymentStepProperties(action octopusdeploy.DeploymentAction, tfStep map[string]interface{}) {
+	if !getBoolProperty(action.Properties, "Octopus.Action.StructuredConfigurationVariables.Enabled") {
+		return
+	}
+
+	tfStep["structured_variable_replacement"] = []interface{}{
+		map[string]interface{}{
+			"target_files": getListProperty(action.Properties, "Octopus.Action.Package.JsonConfigurationVariablesTargets"),
+		},
+	}
+}
+
+func addCustomScriptsDeploymentStepProperties(localStep map[string]interface{}, deploymentStep *octopusdeploy.DeploymentStep) error {
+	action := &deploymentStep.Actions[0]
+	scriptSyntax := getCustomScriptSyntax(localStep["custom_scripts_syntax"].(string))
+	hasCustomScripts := false
+
+	for _, stage := range customScriptStages {
+		script := localStep[stage.attribute].(string)
+		fileName := localStep[stage.attribute+"_file_name"].(string)
+
+		// need to validate here as ConflictsWith cannot be used inside a schema.TypeList
+		if script != "" && fileName != "" {
+			return fmt.Errorf("step %s cannot set both %s and %s_file_name", deploymentStep.Name, stage.attribute, stage.attribute)
+		}
+
+		if fileName != "" {
+			if strings.ContainsAny(fileName, "'\"\n") {
+				return fmt.Errorf("step %s cannot have quotes or line breaks in %s_file_name", deploymentStep.Name, stage.attribute)
+			}
+
+			script = fmt.Sprintf(scriptSyntax.packageFileScript, fileName)
+		}
+
+		if script != "" {
+			action.Properties[fmt.Sprintf("Octopus.Action.CustomScripts.%s.%s", stage.stage, scriptSyntax.fileExtension)] = script
+			hasCustomScripts = true
+		}
+	}
+
+	if hasCustomScripts {
+		addEnabledFeature(action, "Octopus.Features.CustomScripts")
+	}
+
+	return nil
+}
+
+func flattenCustomScriptsDeploymentStepProperties(action octopusdeploy.DeploymentAction, tfStep map[string]interface{}) {
+	tfStep["custom_scripts_syntax"] = "PowerShell"
+
+	// the scripts are kept when the feature is turned off in Octopus, but no longer run
+	if !hasEnabledFeature(action, "Octopus.Features.CustomScripts") {
+		return
+	}
+
+	for _, scriptSyntax := range customScriptSyntaxes {
+		for _, stage := range customScriptStages {
+			script, ok := action.Properties[fmt.Sprintf("Octopus.Action.CustomScripts.%s.%s", stage.stage, scriptSyntax.fileExtension)]
+
+			if !ok {
+				continue
+			}
+
+			tfStep["custom_scripts_syntax"] = scriptSyntax.syntax
+
+			if fileName, ok := parsePackageFileScript(scriptSyntax, script); ok {
+				tfStep[stage.attribute+"_file_name"] = fileName
+			} else {
+				tfStep[stage.attribute] = script
+			}
+		}
+	}
+}
+
+// buildDeploymentStepWindowsService builds a Windows Service step
+func buildDeploymentStepWindowsService(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.WindowsService")
+	action := &deploymentStep.Actions[0]
+
+	addEnabledFeature(action, "Octopus.Features.WindowsService")
+	addFeedAndPackageDeploymentStepProperties(localStep, action)
+	addConfigurationTransformDeploymentStepProperties(localStep, action)
+	addCustomInstallationDirectoryDeploymentStepProperties(localStep, action)
+	addSubstituteInFilesDeploymentStepProperties(localStep, action)
+
+	if err := addCustomScriptsDeploymentStepProperties(localStep, deploymentStep); err != nil {
+		return nil, err
+	}
+
+	if err := addStructuredVariableReplacementDeploymentStepProperties(localStep, deploymentStep); err != nil {
+		return nil, err
+	}
+
+	customAccountName := localStep["custom_account_name"].(string)
+	customAccountPassword := localStep["custom_account_password"].(string)
+	serviceAccount := localStep["service_account"].(string)
+
+	action.Properties["Octopus.Action.WindowsService.CreateOrUpdateService"] = "True"
+	action.Properties["Octopus.Action.WindowsService.ServiceAccount"] = serviceAccount
+	action.Properties["Octopus.Action.WindowsService.StartMode"] = localStep["service_start_mode"].(string)
+	action.Properties["Octopus.Action.WindowsService.ServiceName"] = localStep["service_name"].(string)
+	action.Properties["Octopus.Action.WindowsService.ExecutablePath"] = localStep["executable_path"].(string)
+	action.Properties["Octopus.Action.WindowsService.DesiredStatus"] = localStep["service_desired_status"].(string)
+
+	optionalProperties := map[string]string{
+		"Octopus.Action.WindowsService.Arguments":    localStep["arguments"].(string),
+		"Octopus.Action.WindowsService.Dependencies": strings.Join(getSliceFromTerraformTypeList(localStep["service_dependencies"]), ","),
+		"Octopus.Action.WindowsService.Description":  localStep["service_description"].(string),
+		"Octopus.Action.WindowsService.DisplayName":  localStep["service_display_name"].(string),
+	}
+
+	for key, value := range optionalProperties {
+		if value != "" {
+			action.Properties[key] = value
+		}
+	}
+
+	// need to validate here as the custom account fields depend on the service account
+	if serviceAccount != "_CUSTOM" {
+		if customAccountName != "" || customAccountPassword != "" {
+			return nil, fmt.Errorf("step %s can only set custom_account_name and custom_account_password when its service_account is _CUSTOM", deploymentStep.Name)
+		}
+
+		return deploymentStep, nil
+	}
+
+	if customAccountName == "" {
+		return nil, fmt.Errorf("step %s must set custom_account_name as its service_account is _CUSTOM", deploymentStep.Name)
+	}
+
+	action.Properties["Octopus.Action.WindowsService.CustomAccountName"] = customAccountName
+
+	if customAccountPassword != "" {
+		action.SensitiveProperties = map[string]octopusdeploy.SensitivePropertyValue{
+			"Octopus.Action.WindowsService.CustomAccountPassword": {
+				HasValue: true,
+				NewValue: &customAccountPassword,
+			},
+		}
+	}
+
+	return deploymentStep, nil
+}
+
+// flattenDeploymentStepWindowsService converts a Windows service step into the schema
+func flattenDeploymentStepWindowsService(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
+
+	flattenFeedAndPackageDeploymentStepProperties(action, tfStep)
+	flattenConfigurationTransformDeploymentStepProperties(action, tfStep)
+	flattenCustomScriptsDeploymentStepProperties(action, tfStep)
+	flattenCustomInstallationDirectoryDeploymentStepProperties(action, tfStep)
+	flattenStructuredVariableReplacementDeploymentStepProperties(action, tfStep)
+	flattenSubstituteInFilesDeploymentStepProperties(action, tfStep)
+
+	tfStep["service_account"] = action.Properties["Octopus.Action.WindowsService.ServiceAccount"]
+	tfStep["service_start_mode"] = action.Properties["Octopus.Action.WindowsService.StartMode"]
+	tfStep["service_name"] = action.Properties["Octopus.Action.WindowsService.ServiceName"]
+	tfStep["executable_path"] = action.Properties["Octopus.Action.WindowsService.ExecutablePath"]
+	tfStep["custom_account_name"] = action.Properties["Octopus.Action.WindowsService.CustomAccountName"]
+	tfStep["arguments"] = action.Properties["Octopus.Action.WindowsService.Arguments"]
+	tfStep["service_description"] = action.Properties["Octopus.Action.WindowsService.Description"]
+	tfStep["service_display_name"] = action.Properties["Octopus.Action.WindowsService.DisplayName"]
+	tfStep["service_desired_status"] = "Default"
+
+	if desiredStatus := action.Properties["Octopus.Action.WindowsService.DesiredStatus"]; desiredStatus != "" {
+		tfStep["service_desired_status"] = desiredStatus
+	}
+
+	if dependencies := action.Properties["Octopus.Action.WindowsService.Dependencies"]; dependencies != "" {
+		tfStep["service_dependencies"] = strings.Split(dependencies, ",")
+	}
+
+	return tfStep
+}
+
+// buildDeploymentStepIISWebsite builds an IIS website, virtual directory or web application step
+func buildDeploymentStepIISWebsite(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.IIS")
+	action := &deploymentStep.Actions[0]
+
+	addEnabledFeature(action, "Octopus.Features.IISWebSite")
+	addFeedAndPackageDeploymentStepProperties(localStep, action)
+	addConfigurationTransformDeploymentStepProperties(localStep, action)
+	addSubstituteInFilesDeploymentStepProperties(localStep, action)
+
+	if err := addCustomScriptsDeploymentStepProperties(localStep, deploymentStep); err != nil {
+		return nil, err
+	}
+
+	if err := addStructuredVariableReplacementDeploymentStepProperties(localStep, deploymentStep); err != nil {
+		return nil, err
+	}
+
+	applicationPoolFramework := localStep["application_pool_framework"].(string)
+	applicationPoolIdentity := localStep["application_pool_identity"].(string)
+	applicationPoolName := localStep["application_pool_name"].(string)
+	deploymentType := localStep["deployment_type"].(string)
+	parentWebsiteName := localStep["parent_website_name"].(string)
+	stepName := deploymentStep.Name
+	virtualPath := localStep["virtual_path"].(string)
+	websiteName := localStep["website_name"].(string)
+
+	action.Properties["Octopus.Action.IISWebSite.DeploymentType"] = deploymentType
+	action.Properties["Octopus.Action.IISWebSite.EnableAnonymousAuthentication"] = strconv.FormatBool(localStep["anonymous_authentication"].(bool))
+	action.Properties["Octopus.Action.IISWebSite.EnableBasicAuthentication"] = strconv.FormatBool(localStep["basic_authentication"].(bool))
+	action.Properties["Octopus.Action.IISWebSite.EnableWindowsAuthentication"] = strconv.FormatBool(localStep["windows_authentication"].(bool))
+
+	if deploymentType != "webSite" && len(localStep["binding"].([]interface{})) > 0 {
+		return nil, fmt.Errorf("step %s can only have binding blocks when its deployment_type is webSite", stepName)
+	}
+
+	switch deploymentType {
+	case "webSite":
+		// need to validate here as the required fields depend on the deployment type
+		if websiteName == "" || applicationPoolName == "" {
+			return nil, fmt.Errorf("step %s must set website_name and application_pool_name as its deployment_type is webSite", stepName)
+		}
+
+		bindings, err := buildIISBindings(stepName, localStep["binding"].([]interface{}))
+
+		if err != nil {
+			return nil, err
+		}
+
+		action.Properties["Octopus.Action.IISWebSite.CreateOrUpdateWebSite"] = "True"
+		action.Properties["Octopus.Action.IISWebSite.Bindings"] = bindings
+		action.Properties["Octopus.Action.IISWebSite.ApplicationPoolFrameworkVersion"] = applicationPoolFramework
+		action.Properties["Octopus.Action.IISWebSite.ApplicationPoolIdentityType"] = applicationPoolIdentity
+		action.Properties["Octopus.Action.IISWebSite.ApplicationPoolName"] = applicationPoolName
+		action.Properties["Octopus.Action.IISWebSite.WebRootType"] = "packageRoot"
+		action.Properties["Octopus.Action.IISWebSite.StartApplicationPool"] = "True"
+		action.Properties["Octopus.Action.IISWebSite.StartWebSite"] = "True"
+		action.Properties["Octopus.Action.IISWebSite.WebSiteName"] = websiteName
+	case "virtualDirectory":
+		if parentWebsiteName == "" || virtualPath == "" {
+			return nil, fmt.Errorf("step %s must set parent_website_name and virtual_path as its deployment_type is virtualDirectory", stepName)
+		}
+
+		action.Properties["Octopus.Action.IISWebSite.VirtualDirectory.CreateOrUpdate"] = "True"
+		action.Properties["Octopus.Action.IISWebSite.VirtualDirectory.WebSiteName"] = parentWebsiteName
+		action.Properties["Octopus.Action.IISWebSite.VirtualDirectory.VirtualPath"] = virtualPath
+		action.Properties["Octopus.Action.IISWebSite.VirtualDirectory.WebRootType"] = "packageRoot"
+	case "webApplication":
+		if parentWebsiteName == "" || virtualPath == "" || applicationPoolName == "" {
+			return nil, fmt.Errorf("step %s must set parent_website_name, virtual_path and application_pool_name as its deployment_type is webApplication", stepName)
+		}
+
+		action.Properties["Octopus.Action.IISWebSite.WebApplication.CreateOrUpdate"] = "True"
+		action.Properties["Octopus.Action.IISWebSite.WebApplication.WebSiteName"] = parentWebsiteName
+		action.Properties["Octopus.Action.IISWebSite.WebApplication.VirtualPath"] = virtualPath
+		action.Properties["Octopus.Action.IISWebSite.WebApplication.WebRootType"] = "packageRoot"
+		action.Properties["Octopus.Action.IISWebSite.WebApplication.ApplicationPoolFrameworkVersion"] = applicationPoolFramework
+		action.Properties["Octopus.Action.IISWebSite.WebApplication.ApplicationPoolIdentityType"] = applicationPoolIdentity
+		action.Properties["Octopus.Action.IISWebSite.WebApplication.ApplicationPoolName"] = applicationPoolName
+	}
+
+	return deploymentStep, nil
+}
+
+// flattenDeploymentStepIISWebsite converts an IIS website, virtual directory or web application step into the schema
+func flattenDeploymentStepIISWebsite(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
+
+	flattenFeedAndPackageDeploymentStepProperties(action, tfStep)
+	flattenConfigurationTransformDeploymentStepProperties(action, tfStep)
+	flattenCustomScriptsDeploymentStepProperties(action, tfStep)
+	flattenStructuredVariableReplacementDeploymentStepProperties(action, tfStep)
+	flattenSubstituteInFilesDeploymentStepProperties(action, tfStep)
+
+	deploymentType := action.Properties["Octopus.Action.IISWebSite.DeploymentType"]
+
+	tfStep["deployment_type"] = deploymentType
+	tfStep["anonymous_authentication"] = getBoolProperty(action.Properties, "Octopus.Action.IISWebSite.EnableAnonymousAuthentication")
+	tfStep["basic_authentication"] = getBoolProperty(action.Properties, "Octopus.Action.IISWebSite.EnableBasicAuthentication")
+	tfStep["windows_authentication"] = getBoolProperty(action.Properties, "Octopus.Action.IISWebSite.EnableWindowsAuthentication")
+	tfStep["application_pool_framework"] = "v4.0"
+	tfStep["application_pool_identity"] = "ApplicationPoolIdentity"
+
+	switch deploymentType {
+	case "webSite":
+		tfStep["website_name"] = action.Properties["Octopus.Action.IISWebSite.WebSiteName"]
+		tfStep["application_pool_framework"] = action.Properties["Octopus.Action.IISWebSite.ApplicationPoolFrameworkVersion"]
+		tfStep["application_pool_identity"] = action.Properties["Octopus.Action.IISWebSite.ApplicationPoolIdentityType"]
+		tfStep["application_pool_name"] = action.Properties["Octopus.Action.IISWebSite.ApplicationPoolName"]
+		tfStep["binding"] = flattenIISBindings(action.Properties["Octopus.Action.IISWebSite.Bindings"])
+	case "virtualDirectory":
+		tfStep["parent_website_name"] = action.Properties["Octopus.Action.IISWebSite.VirtualDirectory.WebSiteName"]
+		tfStep["virtual_path"] = action.Properties["Octopus.Action.IISWebSite.VirtualDirectory.VirtualPath"]
+	case "webApplication":
+		tfStep["parent_website_name"] = action.Properties["Octopus.Action.IISWebSite.WebApplication.WebSiteName"]
+		tfStep["virtual_path"] = action.Properties["Octopus.Action.IISWebSite.WebApplication.VirtualPath"]
+		tfStep["application_pool_framework"] = action.Properties["Octopus.Action.IISWebSite.WebApplication.ApplicationPoolFrameworkVersion"]
+		tfStep["application_pool_identity"] = action.Properties["Octopus.Action.IISWebSite.WebApplication.ApplicationPoolIdentityType"]
+		tfStep["application_pool_name"] = action.Properties["Octopus.Action.IISWebSite.WebApplication.ApplicationPoolName"]
+	}
+
+	return tfStep
+}
+
+// buildDeploymentStepInlineScript builds a run a script step with the script in the step
+func buildDeploymentStepInlineScript(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.Script")
+	action := &deploymentStep.Actions[0]
+
+	action.Properties["Octopus.Action.RunOnServer"] = strconv.FormatBool(localStep["run_on_server"].(bool))
+	action.Properties["Octopus.Action.Script.ScriptSource"] = "Inline"
+	action.Properties["Octopus.Action.Script.ScriptBody"] = localStep["script_body"].(string)
+	action.Properties["Octopus.Action.Script.Syntax"] = localStep["script_type"].(string)
+
+	return deploymentStep, nil
+}
+
+// flattenDeploymentStepInlineScript converts a run a script step with the script in the step into the schema
+func flattenDeploymentStepInlineScript(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
+
+	tfStep["run_on_server"] = getBoolProperty(action.Properties, "Octopus.Action.RunOnServer")
+	tfStep["script_body"] = action.Properties["Octopus.Action.Script.ScriptBody"]
+	tfStep["script_type"] = action.Properties["Octopus.Action.Script.Syntax"]
+
+	return tfStep
+}
+
+// buildDeploymentStepPackageScript builds a run a script step with the script in a package
+func buildDeploymentStepPackageScript(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.Script")
+	action := &deploymentStep.Actions[0]
+
+	addFeedAndPackageDeploymentStepProperties(localStep, action)
+	addSubstituteInFilesDeploymentStepProperties(localStep, action)
+
+	action.Properties["Octopus.Action.RunOnServer"] = strconv.FormatBool(localStep["run_on_server"].(bool))
+	action.Properties["Octopus.Action.Script.ScriptSource"] = "Package"
+	action.Properties["Octopus.Action.Script.ScriptFileName"] = localStep["script_file_name"].(string)
+	action.Properties["Octopus.Action.Script.ScriptParameters"] = localStep["script_parameters"].(string)
+
+	return deploymentStep, nil
+}
+
+// flattenDeploymentStepPackageScript converts a run a script step with the script in a package into the schema
+func flattenDeploymentStepPackageScript(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
+
+	flattenFeedAndPackageDeploymentStepProperties(action, tfStep)
+	flattenSubstituteInFilesDeploymentStepProperties(action, tfStep)
+
+	tfStep["run_on_server"] = getBoolProperty(action.Properties, "Octopus.Action.RunOnServer")
+	tfStep["script_file_name"] = action.Properties["Octopus.Action.Script.ScriptFileName"]
+	tfStep["script_parameters"] = action.Properties["Octopus.Action.Script.ScriptParameters"]
+
+	return tfStep
+}
+
+// buildDeploymentStepPackageExtract builds a deploy a package step
+func buildDeploymentStepPackageExtract(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.TentaclePackage")
+	action := &deploymentStep.Actions[0]
+
+	addFeedAndPackageDeploymentStepProperties(localStep, action)
+	addConfigurationTransformDeploymentStepProperties(localStep, action)
+	addCustomInstallationDirectoryDeploymentStepProperties(localStep, action)
+	addSubstituteInFilesDeploymentStepProperties(localStep, action)
+
+	if err := addCustomScriptsDeploymentStepProperties(localStep, deploymentStep); err != nil {
+		return nil, err
+	}
+
+	if err := addStructuredVariableReplacementDeploymentStepProperties(localStep, deploymentStep); err != nil {
+		return nil, err
+	}
+
+	return deploymentStep, nil
+}
+
+// flattenDeploymentStepPackageExtract converts a deploy a package step into the schema
+func flattenDeploymentStepPackageExtract(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
+
+	flattenFeedAndPackageDeploymentStepProperties(action, tfStep)
+	flattenConfigurationTransformDeploymentStepProperties(action, tfStep)
+	flattenCustomInstallationDirectoryDeploymentStepProperties(action, tfStep)
+	flattenSubstituteInFilesDeploymentStepProperties(action, tfStep)
+	flattenStructuredVariableReplacementDeploymentStepProperties(action, tfStep)
+	flattenCustomScriptsDeploymentStepProperties(action, tfStep)
+
+	return tfStep
+}
+
+// buildDeploymentStepManualIntervention builds a manual intervention step
+func buildDeploymentStepManualIntervention(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.Manual")
+	action := &deploymentStep.Actions[0]
+
+	action.Properties["Octopus.Action.Manual.Instructions"] = localStep["instructions"].(string)
+	action.Properties["Octopus.Action.Manual.BlockConcurrentDeployments"] = strconv.FormatBool(localStep["block_deployments"].(bool))
+
+	if responsibleTeams := getSliceFromTerraformTypeList(localStep["responsible_teams"]); len(responsibleTeams) > 0 {
+		action.Properties["Octopus.Action.Manual.ResponsibleTeamIds"] = strings.Join(responsibleTeams, ",")
+	}
+
+	return deploymentStep, nil
+}
+
+// flattenDeploymentStepManualIntervention converts a manual intervention step into the schema
+func flattenDeploymentStepManualIntervention(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
+
+	tfStep["instructions"] = action.Properties["Octopus.Action.Manual.Instructions"]
+	tfStep["block_deployments"] = getBoolProperty(action.Properties, "Octopus.Action.Manual.BlockConcurrentDeployments")
+
+	if responsibleTeams := action.Properties["Octopus.Action.Manual.ResponsibleTeamIds"]; responsibleTeams != "" {
+		tfStep["responsible_teams"] = strings.Split(responsibleTeams, ",")
+	}
+
+	return tfStep
+}
+
+// kubernetesKeyValue is a key value pair with an option, as stored in the Octopus.Action.KubernetesContainers properties
+type kubernetesKeyValue struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Option string `json:"option,omitempty"`
+}
+
+// kubernetesContainer is a container, as stored in the Octopus.Action.KubernetesContainers.Containers property
+type kubernetesContainer struct {
+	Name                 string               `json:"Name"`
+	Ports                []kubernetesKeyValue `json:"Ports"`
+	EnvironmentVariables []kubernetesKeyValue `json:"EnvironmentVariables"`
+}
+
+// kubernetesServicePort is a service port, as stored in the Octopus.Action.KubernetesContainers.ServicePorts property
+type kubernetesServicePort struct {
+	Name       string `json:"name"`
+	Port       string `json:"port"`
+	TargetPort string `json:"targetPort"`
+	Protocol   string `json:"protocol"`
+}
+
+// buildDeploymentStepKubernetesContainers builds a deploy Kubernetes containers step
+func buildDeploymentStepKubernetesContainers(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.KubernetesDeployContainers")
+	action := &deploymentStep.Actions[0]
+
+	addFeedAndPackageDeploymentStepProperties(localStep, action)
+
+	container := kubernetesContainer{
+		Name:                 localStep["container_name"].(string),
+		Ports:                []kubernetesKeyValue{},
+		EnvironmentVariables: []kubernetesKeyValue{},
+	}
+
+	for _, raw := range localStep["container_port"].([]interface{}) {
+		tfPort := raw.(map[string]interface{})
+
+		container.Ports = append(container.Ports, kubernetesKeyValue{
+			Key:    tfPort["name"].(string),
+			Value:  strconv.Itoa(tfPort["port"].(int)),
+			Option: tfPort["protocol"].(string),
+		})
+	}
+
+	environmentVariables := localStep["environment_variables"].(map[string]interface{})
+
+	for _, name := range getSortedMapKeys(environmentVariables) {
+		container.EnvironmentVariables = append(container.EnvironmentVariables, kubernetesKeyValue{
+			Key:   name,
+			Value: environmentVariables[name].(string),
+		})
+	}
+
+	containersJSON, err := json.Marshal([]kubernetesContainer{container})
+
+	if err != nil {
+		return nil, fmt.Errorf("error building containers of step %s: %s", deploymentStep.Name, err.Error())
+	}
+
+	action.Properties["Octopus.Action.KubernetesContainers.DeploymentName"] = localStep["deployment_name"].(string)
+	action.Properties["Octopus.Action.KubernetesContainers.DeploymentResourceType"] = "Deployment"
+	action.Properties["Octopus.Action.KubernetesContainers.Namespace"] = localStep["namespace"].(string)
+	action.Properties["Octopus.Action.KubernetesContainers.Replicas"] = strconv.Itoa(localStep["replicas"].(int))
+	action.Properties["Octopus.Action.KubernetesContainers.DeploymentStyle"] = localStep["deployment_style"].(string)
+	action.Properties["Octopus.Action.KubernetesContainers.Containers"] = string(containersJSON)
+
+	if serviceName := localStep["service_name"].(string); serviceName != "" {
+		servicePorts := []kubernetesServicePort{}
+
+		for _, raw := range localStep["service_port"].([]interface{}) {
+			tfPort := raw.(map[string]interface{})
+
+			servicePorts = append(servicePorts, kubernetesServicePort{
+				Name:       tfPort["name"].(string),
+				Port:       strconv.Itoa(tfPort["port"].(int)),
+				TargetPort: tfPort["target_port"].(string),
+				Protocol:   tfPort["protocol"].(string),
+			})
+		}
+
+		servicePortsJSON, err := json.Marshal(servicePorts)
+
+		if err != nil {
+			return nil, fmt.Errorf("error building service ports of step %s: %s", deploymentStep.Name, err.Error())
+		}
+
+		action.Properties["Octopus.Action.KubernetesContainers.ServiceName"] = serviceName
+		action.Properties["Octopus.Action.KubernetesContainers.ServiceType"] = localStep["service_type"].(string)
+		action.Properties["Octopus.Action.KubernetesContainers.ServicePorts"] = string(servicePortsJSON)
+	} else if len(localStep["service_port"].([]interface{})) > 0 {
+		return nil, fmt.Errorf("step %s can only have service_port blocks when service_name is set", deploymentStep.Name)
+	}
+
+	return deploymentStep, nil
+}
+
+// flattenDeploymentStepKubernetesContainers converts a deploy Kubernetes containers step into the schema
+func flattenDeploymentStepKubernetesContainers(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
+
+	flattenFeedAndPackageDeploymentStepProperties(action, tfStep)
+
+	replicas, _ := strconv.Atoi(action.Properties["Octopus.Action.KubernetesContainers.Replicas"])
+
+	tfStep["deployment_name"] = action.Properties["Octopus.Action.KubernetesContainers.DeploymentName"]
+	tfStep["namespace"] = action.Properties["Octopus.Action.KubernetesContainers.Namespace"]
+	tfStep["replicas"] = replicas
+	tfStep["deployment_style"] = action.Properties["Octopus.Action.KubernetesContainers.DeploymentStyle"]
+	tfStep["service_name"] = action.Properties["Octopus.Action.KubernetesContainers.ServiceName"]
+	tfStep["service_type"] = "ClusterIP"
+
+	var containers []kubernetesContainer
+
+	if err := json.Unmarshal([]byte(action.Properties["Octopus.Action.KubernetesContainers.Containers"]), &containers); err == nil && len(containers) > 0 {
+		var tfPorts []interface{}
+
+		for _, port := range containers[0].Ports {
+			portNumber, _ := strconv.Atoi(port.Value)
+
+			tfPorts = append(tfPorts, map[string]interface{}{
+				"name":     port.Key,
+				"port":     portNumber,
+				"protocol": port.Option,
+			})
+		}
+
+		environmentVariables := make(map[string]interface{})
+
+		for _, environmentVariable := range containers[0].EnvironmentVariables {
+			environmentVariables[environmentVariable.Key] = environmentVariable.Value
+		}
+
+		tfStep["container_name"] = containers[0].Name
+		tfStep["container_port"] = tfPorts
+		tfStep["environment_variables"] = environmentVariables
+	}
+
+	if serviceType := action.Properties["Octopus.Action.KubernetesContainers.ServiceType"]; serviceType != "" {
+		tfStep["service_type"] = serviceType
+	}
+
+	var servicePorts []kubernetesServicePort
+
+	if err := json.Unmarshal([]byte(action.Properties["Octopus.Action.KubernetesContainers.ServicePorts"]), &servicePorts); err == nil {
+		var tfServicePorts []interface{}
+
+		for _, servicePort := range servicePorts {
+			portNumber, _ := strconv.Atoi(servicePort.Port)
+
+			tfServicePorts = append(tfServicePorts, map[string]interface{}{
+				"name":        servicePort.Name,
+				"port":        portNumber,
+				"target_port": servicePort.TargetPort,
+				"protocol":    servicePort.Protocol,
+			})
+		}
+
+		tfStep["service_port"] = tfServicePorts
+	}
+
+	return tfStep
+}
+
+// buildDeploymentStepKubernetesRawYaml builds a deploy raw Kubernetes YAML step
+func buildDeploymentStepKubernetesRawYaml(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.KubernetesDeployRawYaml")
+	action := &deploymentStep.Actions[0]
+
+	action.Properties["Octopus.Action.Script.ScriptSource"] = "Inline"
+	action.Properties["Octopus.Action.KubernetesContainers.CustomResourceYaml"] = localStep["yaml"].(string)
+	action.Properties["Octopus.Action.KubernetesContainers.Namespace"] = localStep["namespace"].(string)
+
+	return deploymentStep, nil
+}
+
+// flattenDeploymentStepKubernetesRawYaml converts a deploy raw Kubernetes YAML step into the schema
+func flattenDeploymentStepKubernetesRawYaml(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
+
+	tfStep["yaml"] = action.Properties["Octopus.Action.KubernetesContainers.CustomResourceYaml"]
+	tfStep["namespace"] = action.Properties["Octopus.Action.KubernetesContainers.Namespace"]
+
+	return tfStep
+}
+
+// buildDeploymentStepHelmChartUpgrade builds an upgrade a Helm chart step
+func buildDeploymentStepHelmChartUpgrade(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.HelmChartUpgrade")
+	action := &deploymentStep.Actions[0]
+
+	addFeedAndPackageDeploymentStepProperties(localStep, action)
+
+	action.Properties["Octopus.Action.Helm.ReleaseName"] = localStep["release_name"].(string)
+	action.Properties["Octopus.Action.Helm.Namespace"] = localStep["namespace"].(string)
+	action.Properties["Octopus.Action.Helm.ResetValues"] = strconv.FormatBool(localStep["reset_values"].(bool))
+	action.Properties["Octopus.Action.Helm.AdditionalArgs"] = localStep["additional_args"].(string)
+
+	if valuesFiles := getSliceFromTerraformTypeList(localStep["values_files"]); len(valuesFiles) > 0 {
+		action.Properties["Octopus.Action.Helm.ValuesFilePath"] = strings.Join(valuesFiles, "\n")
+	}
+
+	if valuesYaml := localStep["values_yaml"].(string); valuesYaml != "" {
+		action.Properties["Octopus.Action.Helm.YamlValues"] = valuesYaml
+	}
+
+	if keyValues := localStep["key_values"].(map[string]interface{}); len(keyValues) > 0 {
+		keyValuesJSON, err := json.Marshal(keyValues)
+
+		if err != nil {
+			return nil, fmt.Errorf("error building key values of step %s: %s", deploymentStep.Name, err.Error())
+		}
+
+		action.Properties["Octopus.Action.Helm.KeyValues"] = string(keyValuesJSON)
+	}
+
+	return deploymentStep, nil
+}
+
+// flattenDeploymentStepHelmChartUpgrade converts an upgrade a Helm chart step into the schema
+func flattenDeploymentStepHelmChartUpgrade(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
+
+	flattenFeedAndPackageDeploymentStepProperties(action, tfStep)
+
+	tfStep["release_name"] = action.Properties["Octopus.Action.Helm.ReleaseName"]
+	tfStep["namespace"] = action.Properties["Octopus.Action.Helm.Namespace"]
+	tfStep["reset_values"] = getBoolProperty(action.Properties, "Octopus.Action.Helm.ResetValues")
+	tfStep["additional_args"] = action.Properties["Octopus.Action.Helm.AdditionalArgs"]
+	tfStep["values_files"] = getListProperty(action.Properties, "Octopus.Action.Helm.ValuesFilePath")
+	tfStep["values_yaml"] = action.Properties["Octopus.Action.Helm.YamlValues"]
+
+	var keyValues map[string]interface{}
+
+	if err := json.Unmarshal([]byte(action.Properties["Octopus.Action.Helm.KeyValues"]), &keyValues); err == nil {
+		tfStep["key_values"] = keyValues
+	}
+
+	return tfStep
+}
+
+// buildDeploymentStepKubectlScript builds a run a kubectl script step
+func buildDeploymentStepKubectlScript(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.KubernetesRunScript")
+	action := &deploymentStep.Actions[0]
+
+	action.Properties["Octopus.Action.Script.ScriptSource"] = "Inline"
+	action.Properties["Octopus.Action.Script.Syntax"] = localStep["script_type"].(string)
+	action.Properties["Octopus.Action.Script.ScriptBody"] = localStep["script_body"].(string)
+
+	return deploymentStep, nil
+}
+
+// flattenDeploymentStepKubectlScript converts a run a kubectl script step into the schema
+func flattenDeploymentStepKubectlScript(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
+
+	tfStep["script_type"] = action.Properties["Octopus.Action.Script.Syntax"]
+	tfStep["script_body"] = action.Properties["Octopus.Action.Script.ScriptBody"]
+
+	return tfStep
+}
+
+func addAwsAccountDeploymentStepProperties(localStep map[string]interface{}, deploymentStep *octopusdeploy.DeploymentStep) error {
+	action := &deploymentStep.Actions[0]
+	awsAccountVariable := localStep["aws_account_variable"].(string)
+	useInstanceRole := localStep["use_instance_role"].(bool)
+
+	// need to validate here as ConflictsWith cannot be used inside a schema.TypeList
+	if (awsAccountVariable == "") != useInstanceRole {
+		return fmt.Errorf("step %s must set exactly one of aws_account_variable or use_instance_role", deploymentStep.Name)
+	}
+
+	action.Properties["Octopus.Action.RunOnServer"] = "true"
+	action.Properties["Octopus.Action.AwsAccount.UseInstanceRole"] = strconv.FormatBool(useInstanceRole)
+	action.Properties["Octopus.Action.AwsAccount.Variable"] = awsAccountVariable
+	action.Properties["Octopus.Action.Aws.AssumeRole"] = "False"
+	action.Properties["Octopus.Action.Aws.Region"] = localStep["region"].(string)
+
+	return nil
+}
+
+func flattenAwsAccountDeploymentStepProperties(action octopusdeploy.DeploymentAction, tfStep map[string]interface{}) {
+	tfStep["aws_account_variable"] = action.Properties["Octopus.Action.AwsAccount.Variable"]
+	tfStep["use_instance_role"] = getBoolProperty(action.Properties, "Octopus.Action.AwsAccount.UseInstanceRole")
+	tfStep["region"] = action.Properties["Octopus.Action.Aws.Region"]
+}
+
+func addAzureAccountDeploymentStepProperties(localStep map[string]interface{}, action *octopusdeploy.DeploymentAction) {
+	action.Properties["Octopus.Action.RunOnServer"] = "true"
+	action.Properties["Octopus.Action.Azure.AccountId"] = localStep["azure_account_id"].(string)
+}
+
+func flattenAzureAccountDeploymentStepProperties(action octopusdeploy.DeploymentAction, tfStep map[string]interface{}) {
+	tfStep["azure_account_id"] = action.Properties["Octopus.Action.Azure.AccountId"]
+}
+
+// cloudFormationParameter is a template parameter, as stored in the Octopus.Action.Aws.CloudFormationTemplateParameters property
+type cloudFormationParameter struct {
+	ParameterKey   string `json:"ParameterKey"`
+	ParameterValue string `json:"ParameterValue"`
+}
+
+// buildDeploymentStepAwsCloudFormation builds a deploy an AWS CloudFormation template step
+func buildDeploymentStepAwsCloudFormation(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.AwsRunCloudFormation")
+	action := &deploymentStep.Actions[0]
+
+	if err := addAwsAccountDeploymentStepProperties(localStep, deploymentStep); err != nil {
+		return nil, err
+	}
+
+	parameters := localStep["parameters"].(map[string]interface{})
+	cloudFormationParameters := []cloudFormationParameter{}
+
+	for _, key := range getSortedMapKeys(parameters) {
+		cloudFormationParameters = append(cloudFormationParameters, cloudFormationParameter{
+			ParameterKey:   key,
+			ParameterValue: parameters[key].(string),
+		})
+	}
+
+	parametersJSON, err := json.Marshal(cloudFormationParameters)
+
+	if err != nil {
+		return nil, fmt.Errorf("error building parameters of step %s: %s", deploymentStep.Name, err.Error())
+	}
+
+	capabilities := getSliceFromTerraformTypeList(localStep["capabilities"])
+
+	if capabilities == nil {
+		capabilities = []string{}
+	}
+
+	capabilitiesJSON, err := json.Marshal(capabilities)
+
+	if err != nil {
+		return nil, fmt.Errorf("error building capabilities of step %s: %s", deploymentStep.Name, err.Error())
+	}
+
+	action.Properties["Octopus.Action.Aws.CloudFormationStackName"] = localStep["stack_name"].(string)
+	action.Properties["Octopus.Action.Aws.TemplateSource"] = "Inline"
+	action.Properties["Octopus.Action.Aws.CloudFormationTemplate"] = localStep["template_body"].(string)
+	action.Properties["Octopus.Action.Aws.CloudFormationTemplateParameters"] = string(parametersJSON)
+	action.Properties["Octopus.Action.Aws.IamCapabilities"] = string(capabilitiesJSON)
+	action.Properties["Octopus.Action.Aws.DisableRollBack"] = strconv.FormatBool(localStep["disable_rollback"].(bool))
+	action.Properties["Octopus.Action.Aws.WaitForCompletion"] = strconv.FormatBool(localStep["wait_for_completion"].(bool))
+
+	return deploymentStep, nil
+}
+
+// flattenDeploymentStepAwsCloudFormation converts a deploy an AWS CloudFormation template step into the schema
+func flattenDeploymentStepAwsCloudFormation(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
+
+	flattenAwsAccountDeploymentStepProperties(action, tfStep)
+
+	tfStep["stack_name"] = action.Properties["Octopus.Action.Aws.CloudFormationStackName"]
+	tfStep["template_body"] = action.Properties["Octopus.Action.Aws.CloudFormationTemplate"]
+	tfStep["disable_rollback"] = getBoolProperty(action.Properties, "Octopus.Action.Aws.DisableRollBack")
+	tfStep["wait_for_completion"] = getBoolProperty(action.Properties, "Octopus.Action.Aws.WaitForCompletion")
+
+	var cloudFormationParameters []cloudFormationParameter
+
+	if err := json.Unmarshal([]byte(action.Properties["Octopus.Action.Aws.CloudFormationTemplateParameters"]), &cloudFormationParameters); err == nil {
+		parameters := make(map[string]interface{})
+
+		for _, parameter := range cloudFormationParameters {
+			parameters[parameter.ParameterKey] = parameter.ParameterValue
+		}
+
+		tfStep["parameters"] = parameters
+	}
+
+	var capabilities []string
+
+	if err := json.Unmarshal([]byte(action.Properties["Octopus.Action.Aws.IamCapabilities"]), &capabilities); err == nil {
+		tfStep["capabilities"] = capabilities
+	}
+
+	return tfStep
+}
+
+// s3PackageOptions are the upload options of a whole package, as stored in the Octopus.Action.Aws.S3.PackageOptions property
+type s3PackageOptions struct {
+	BucketKey          string        `json:"bucketKey"`
+	BucketKeyBehaviour string        `json:"bucketKeyBehaviour"`
+	BucketKeyPrefix    string        `json:"bucketKeyPrefix"`
+	CannedACL          string        `json:"cannedAcl"`
+	StorageClass       string        `json:"storageClass"`
+	Metadata           []interface{} `json:"metadata"`
+	Tags               []interface{} `json:"tags"`
+}
+
+// buildDeploymentStepAwsS3Upload builds an upload a package to an AWS S3 bucket step
+func buildDeploymentStepAwsS3Upload(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.AwsUploadS3")
+	action := &deploymentStep.Actions[0]
+
+	if err := addAwsAccountDeploymentStepProperties(localStep, deploymentStep); err != nil {
+		return nil, err
+	}
+
+	addFeedAndPackageDeploymentStepProperties(localStep, action)
+
+	packageOptions := s3PackageOptions{
+		BucketKey:          localStep["bucket_key"].(string),
+		BucketKeyBehaviour: "Custom",
+		CannedACL:          localStep["canned_acl"].(string),
+		StorageClass:       "STANDARD",
+		Metadata:           []interface{}{},
+		Tags:               []interface{}{},
+	}
+
+	if packageOptions.BucketKey == "" {
+		packageOptions.BucketKeyBehaviour = "Filename"
+	}
+
+	packageOptionsJSON, err := json.Marshal(packageOptions)
+
+	if err != nil {
+		return nil, fmt.Errorf("error building package options of step %s: %s", deploymentStep.Name, err.Error())
+	}
+
+	action.Properties["Octopus.Action.Aws.S3.BucketName"] = localStep["bucket_name"].(string)
+	action.Properties["Octopus.Action.Aws.S3.TargetMode"] = "EntirePackage"
+	action.Properties["Octopus.Action.Aws.S3.PackageOptions"] = string(packageOptionsJSON)
 
-	return schemaToReturn
+	return deploymentStep, nil
 }
 
+// flattenDeploymentStepAwsS3Upload converts an upload a package to an AWS S3 bucket step into the schema
+func flattenDeploymentStepAwsS3Upload(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
 
+	flattenAwsAccountDeploymentStepProperties(action, tfStep)
+	flattenFeedAndPackageDeploymentStepProperties(action, tfStep)
 
-func buildDeploymentProcess(d *schema.ResourceData, deploymentProcess *octopusdeploy.DeploymentProcess) *octopusdeploy.DeploymentProcess {
-	deploymentProcess.Steps = nil // empty the steps
+	tfStep["bucket_name"] = action.Properties["Octopus.Action.Aws.S3.BucketName"]
 
-	if v, ok := d.GetOk("deployment_step_windows_service"); ok {
-		steps := v.([]interface{})
-		for _, raw := range steps {
+	var packageOptions s3PackageOptions
 
-			localStep := raw.(map[string]interface{})
+	if err := json.Unmarshal([]byte(action.Properties["Octopus.Action.Aws.S3.PackageOptions"]), &packageOptions); err == nil {
+		if packageOptions.BucketKeyBehaviour == "Custom" {
+			tfStep["bucket_key"] = packageOptions.BucketKey
+		}
 
-			configurationTransforms := localStep["configuration_transforms"].(bool)
-			configurationVariables := localStep["configuration_variables"].(bool)
-			executablePath := localStep["executable_path"].(string)
-			feedID := localStep["feed_id"].(string)
-			jsonFileVariableReplacement := localStep["json_file_variable_replacement"].(string)
-			packageID := localStep["package"].(string)
-			serviceAccount := localStep["service_account"].(string)
-			serviceName := localStep["service_name"].(string)
-			serviceStartMode := localStep["service_start_mode"].(string)
-			stepCondition := localStep["step_condition"].(string)
-			stepName := localStep["step_name"].(string)
-			stepStartTrigger := localStep["step_start_trigger"].(string)
-
-			deploymentStep := &octopusdeploy.DeploymentStep{
-				Name:               stepName,
-				PackageRequirement: "LetOctopusDecide",
-				Condition:          stepCondition,
-				StartTrigger:       stepStartTrigger,
-				Actions: []octopusdeploy.DeploymentAction{
-					{
-						Name:       stepName,
-						ActionType: "Octopus.WindowsService",
-						Properties: map[string]string{
-							"Octopus.Action.WindowsService.CreateOrUpdateService":                       "True",
-							"Octopus.Action.WindowsService.ServiceAccount":                              serviceAccount,
-							"Octopus.Action.WindowsService.StartMode":                                   serviceStartMode,
-							"Octopus.Action.Package.AutomaticallyRunConfigurationTransformationFiles":   strconv.FormatBool(configurationTransforms),
-							"Octopus.Action.Package.AutomaticallyUpdateAppSettingsAndConnectionStrings": strconv.FormatBool(configurationVariables),
-							"Octopus.Action.EnabledFeatures":                                            "Octopus.Features.WindowsService,Octopus.Features.ConfigurationTransforms,Octopus.Features.ConfigurationVariables",
-							"Octopus.Action.Package.FeedId":                                             feedID,
-							"Octopus.Action.Package.PackageId":                                          packageID,
-							"Octopus.Action.Package.DownloadOnTentacle":                                 "False",
-							"Octopus.Action.WindowsService.ServiceName":                                 serviceName,
-							"Octopus.Action.WindowsService.ExecutablePath":                              executablePath,
-						},
-					},
-				},
-			}
+		tfStep["canned_acl"] = packageOptions.CannedACL
+	}
 
-			if jsonFileVariableReplacement != "" {
-				deploymentStep.Actions[0].Properties["Octopus.Action.Package.JsonConfigurationVariablesTargets"] = jsonFileVariableReplacement
-				deploymentStep.Actions[0].Properties["Octopus.Action.Package.JsonConfigurationVariablesEnabled"] = "True"
+	return tfStep
+}
 
-				deploymentStep.Actions[0].Properties["Octopus.Action.EnabledFeatures"] += ",Octopus.Features.JsonConfigurationVariables"
-			}
+// buildDeploymentStepAwsCliScript builds a run an AWS CLI script step
+func buildDeploymentStepAwsCliScript(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.AwsRunScript")
+	action := &deploymentStep.Actions[0]
 
-			if targetRolesInterface, ok := localStep["target_roles"]; ok {
-				var targetRoleSlice []string
+	if err := addAwsAccountDeploymentStepProperties(localStep, deploymentStep); err != nil {
+		return nil, err
+	}
 
-				targetRoles := targetRolesInterface.([]interface{})
+	action.Properties["Octopus.Action.Script.ScriptSource"] = "Inline"
+	action.Properties["Octopus.Action.Script.Syntax"] = localStep["script_type"].(string)
+	action.Properties["Octopus.Action.Script.ScriptBody"] = localStep["script_body"].(string)
 
-				for _, role := range targetRoles {
-					targetRoleSlice = append(targetRoleSlice, role.(string))
-				}
+	return deploymentStep, nil
+}
 
-				deploymentStep.Properties = map[string]string{"Octopus.Action.TargetRoles": strings.Join(targetRoleSlice, ",")}
-			}
+// flattenDeploymentStepAwsCliScript converts a run an AWS CLI script step into the schema
+func flattenDeploymentStepAwsCliScript(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
 
-			deploymentProcess.Steps = append(deploymentProcess.Steps, *deploymentStep)
-		}
+	flattenAwsAccountDeploymentStepProperties(action, tfStep)
+
+	tfStep["script_type"] = action.Properties["Octopus.Action.Script.Syntax"]
+	tfStep["script_body"] = action.Properties["Octopus.Action.Script.ScriptBody"]
+
+	return tfStep
+}
+
+// buildDeploymentStepAzureAppService builds a deploy an Azure App Service step
+func buildDeploymentStepAzureAppService(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.AzureWebApp")
+	action := &deploymentStep.Actions[0]
+
+	addAzureAccountDeploymentStepProperties(localStep, action)
+	addFeedAndPackageDeploymentStepProperties(localStep, action)
+
+	action.Properties["Octopus.Action.Azure.ResourceGroupName"] = localStep["resource_group_name"].(string)
+	action.Properties["Octopus.Action.Azure.WebAppName"] = localStep["web_app_name"].(string)
+	action.Properties["Octopus.Action.Azure.DeploymentSlot"] = localStep["deployment_slot"].(string)
+	action.Properties["Octopus.Action.Azure.RemoveAdditionalFiles"] = strconv.FormatBool(localStep["remove_additional_files"].(bool))
+
+	if err := addCustomScriptsDeploymentStepProperties(localStep, deploymentStep); err != nil {
+		return nil, err
 	}
 
-	if v, ok := d.GetOk("deployment_step_iis_website"); ok {
-		steps := v.([]interface{})
-		for _, raw := range steps {
+	return deploymentStep, nil
+}
 
-			localStep := raw.(map[string]interface{})
+// flattenDeploymentStepAzureAppService converts a deploy an Azure App Service step into the schema
+func flattenDeploymentStepAzureAppService(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
 
-			anonymousAuthentication := localStep["anonymous_authentication"].(bool)
-			applicationPoolFramework := localStep["application_pool_framework"].(string)
-			applicationPoolIdentity := localStep["application_pool_identity"].(string)
-			applicationPoolName := localStep["application_pool_name"].(string)
-			basicAuthentication := localStep["basic_authentication"].(bool)
-			configurationTransforms := localStep["configuration_transforms"].(bool)
-			configurationVariables := localStep["configuration_variables"].(bool)
-			feedID := localStep["feed_id"].(string)
-			jsonFileVariableReplacement := localStep["json_file_variable_replacement"].(string)
-			packageID := localStep["package"].(string)
-			stepCondition := localStep["step_condition"].(string)
-			stepName := localStep["step_name"].(string)
-			stepStartTrigger := localStep["step_start_trigger"].(string)
-			websiteName := localStep["website_name"].(string)
-			windowsAuthentication := localStep["windows_authentication"].(bool)
-
-			deploymentStep := &octopusdeploy.DeploymentStep{
-				Name:               stepName,
-				PackageRequirement: "LetOctopusDecide",
-				Condition:          stepCondition,
-				StartTrigger:       stepStartTrigger,
-				Actions: []octopusdeploy.DeploymentAction{
-					{
-						Name:       stepName,
-						ActionType: "Octopus.IIS",
-						Properties: map[string]string{
-							"Octopus.Action.IISWebSite.DeploymentType":                                  "webSite",
-							"Octopus.Action.IISWebSite.CreateOrUpdateWebSite":                           "True",
-							"Octopus.Action.IISWebSite.Bindings":                                        "[{\"protocol\":\"http\",\"port\":\"80\",\"host\":\"\",\"thumbprint\":null,\"certificateVariable\":null,\"requireSni\":false,\"enabled\":true}]",
-							"Octopus.Action.IISWebSite.ApplicationPoolFrameworkVersion":                 applicationPoolFramework,
-							"Octopus.Action.IISWebSite.ApplicationPoolIdentityType":                     applicationPoolIdentity,
-							"Octopus.Action.IISWebSite.EnableAnonymousAuthentication":                   strconv.FormatBool(anonymousAuthentication),
-							"Octopus.Action.IISWebSite.EnableBasicAuthentication":                       strconv.FormatBool(basicAuthentication),
-							"Octopus.Action.IISWebSite.EnableWindowsAuthentication":                     strconv.FormatBool(windowsAuthentication),
-							"Octopus.Action.IISWebSite.WebApplication.ApplicationPoolFrameworkVersion":  applicationPoolFramework,
-							"Octopus.Action.IISWebSite.WebApplication.ApplicationPoolIdentityType":      applicationPoolIdentity,
-							"Octopus.Action.Package.AutomaticallyRunConfigurationTransformationFiles":   strconv.FormatBool(configurationTransforms),
-							"Octopus.Action.Package.AutomaticallyUpdateAppSettingsAndConnectionStrings": strconv.FormatBool(configurationVariables),
-							"Octopus.Action.EnabledFeatures":                                            "Octopus.Features.IISWebSite,Octopus.Features.ConfigurationTransforms,Octopus.Features.ConfigurationVariables",
-							"Octopus.Action.Package.FeedId":                                             feedID,
-							"Octopus.Action.Package.DownloadOnTentacle":                                 "False",
-							"Octopus.Action.IISWebSite.WebRootType":                                     "packageRoot",
-							"Octopus.Action.IISWebSite.StartApplicationPool":                            "True",
-							"Octopus.Action.IISWebSite.StartWebSite":                                    "True",
-							"Octopus.Action.Package.PackageId":                                          packageID,
-							"Octopus.Action.IISWebSite.WebSiteName":                                     websiteName,
-							"Octopus.Action.IISWebSite.ApplicationPoolName":                             applicationPoolName,
-						},
-					},
-				},
-			}
+	flattenAzureAccountDeploymentStepProperties(action, tfStep)
+	flattenFeedAndPackageDeploymentStepProperties(action, tfStep)
+	flattenCustomScriptsDeploymentStepProperties(action, tfStep)
 
-			if jsonFileVariableReplacement != "" {
-				deploymentStep.Actions[0].Properties["Octopus.Action.Package.JsonConfigurationVariablesTargets"] = jsonFileVariableReplacement
-				deploymentStep.Actions[0].Properties["Octopus.Action.Package.JsonConfigurationVariablesEnabled"] = "True"
+	tfStep["resource_group_name"] = action.Properties["Octopus.Action.Azure.ResourceGroupName"]
+	tfStep["web_app_name"] = action.Properties["Octopus.Action.Azure.WebAppName"]
+	tfStep["deployment_slot"] = action.Properties["Octopus.Action.Azure.DeploymentSlot"]
+	tfStep["remove_additional_files"] = getBoolProperty(action.Properties, "Octopus.Action.Azure.RemoveAdditionalFiles")
 
-				deploymentStep.Actions[0].Properties["Octopus.Action.EnabledFeatures"] += ",Octopus.Features.JsonConfigurationVariables"
-			}
+	return tfStep
+}
 
-			if targetRolesInterface, ok := localStep["target_roles"]; ok {
-				var targetRoleSlice []string
+// armTemplateParameter is the value of a template parameter, as stored in the Octopus.Action.Azure.ResourceGroupTemplateParameters property
+type armTemplateParameter struct {
+	Value string `json:"value"`
+}
 
-				targetRoles := targetRolesInterface.([]interface{})
+// buildDeploymentStepAzureResourceGroup builds a deploy an Azure Resource Manager template step
+func buildDeploymentStepAzureResourceGroup(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.AzureResourceGroup")
+	action := &deploymentStep.Actions[0]
 
-				for _, role := range targetRoles {
-					targetRoleSlice = append(targetRoleSlice, role.(string))
-				}
+	addAzureAccountDeploymentStepProperties(localStep, action)
 
-				deploymentStep.Properties = map[string]string{"Octopus.Action.TargetRoles": strings.Join(targetRoleSlice, ",")}
-			}
+	parameters := make(map[string]armTemplateParameter)
+
+	for key, value := range localStep["parameters"].(map[string]interface{}) {
+		parameters[key] = armTemplateParameter{Value: value.(string)}
+	}
+
+	// maps are marshalled with sorted keys, so the property does not change between applies
+	parametersJSON, err := json.Marshal(parameters)
+
+	if err != nil {
+		return nil, fmt.Errorf("error building parameters of step %s: %s", deploymentStep.Name, err.Error())
+	}
+
+	action.Properties["Octopus.Action.Azure.ResourceGroupName"] = localStep["resource_group_name"].(string)
+	action.Properties["Octopus.Action.Azure.ResourceGroupDeploymentMode"] = localStep["deployment_mode"].(string)
+	action.Properties["Octopus.Action.Azure.TemplateSource"] = "Inline"
+	action.Properties["Octopus.Action.Azure.ResourceGroupTemplate"] = localStep["template_body"].(string)
+	action.Properties["Octopus.Action.Azure.ResourceGroupTemplateParameters"] = string(parametersJSON)
+
+	return deploymentStep, nil
+}
+
+// flattenDeploymentStepAzureResourceGroup converts a deploy an Azure Resource Manager template step into the schema
+func flattenDeploymentStepAzureResourceGroup(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
+
+	flattenAzureAccountDeploymentStepProperties(action, tfStep)
+
+	tfStep["resource_group_name"] = action.Properties["Octopus.Action.Azure.ResourceGroupName"]
+	tfStep["deployment_mode"] = action.Properties["Octopus.Action.Azure.ResourceGroupDeploymentMode"]
+	tfStep["template_body"] = action.Properties["Octopus.Action.Azure.ResourceGroupTemplate"]
+
+	var armParameters map[string]armTemplateParameter
 
-			deploymentProcess.Steps = append(deploymentProcess.Steps, *deploymentStep)
+	if err := json.Unmarshal([]byte(action.Properties["Octopus.Action.Azure.ResourceGroupTemplateParameters"]), &armParameters); err == nil {
+		parameters := make(map[string]interface{})
+
+		for key, parameter := range armParameters {
+			parameters[key] = parameter.Value
 		}
+
+		tfStep["parameters"] = parameters
 	}
 
-	if v, ok := d.GetOk("deployment_step_inline_script"); ok {
-		steps := v.([]interface{})
-		for _, raw := range steps {
+	return tfStep
+}
 
-			localStep := raw.(map[string]interface{})
+// buildDeploymentStepAzureScript builds a run an Azure script step
+func buildDeploymentStepAzureScript(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.AzurePowerShell")
+	action := &deploymentStep.Actions[0]
 
-			scriptType := localStep["script_type"].(string)
-			scriptBody := localStep["script_body"].(string)
-			runOnServer := localStep["run_on_server"].(bool)
-			stepCondition := localStep["step_condition"].(string)
-			stepName := localStep["step_name"].(string)
-			stepStartTrigger := localStep["step_start_trigger"].(string)
-
-			deploymentStep := &octopusdeploy.DeploymentStep{
-				Name:               stepName,
-				PackageRequirement: "LetOctopusDecide",
-				Condition:          stepCondition,
-				StartTrigger:       stepStartTrigger,
-				Actions: []octopusdeploy.DeploymentAction{
-					{
-						Name:       stepName,
-						ActionType: "Octopus.Script",
-						Properties: map[string]string{
-							"Octopus.Action.RunOnServer":                strconv.FormatBool(runOnServer),
-							"Octopus.Action.Script.ScriptSource":        "Inline",
-							"Octopus.Action.Package.DownloadOnTentacle": "False",
-							"Octopus.Action.Script.ScriptBody":          scriptBody,
-							"Octopus.Action.Script.Syntax":              scriptType,
-						},
-					},
-				},
-			}
+	addAzureAccountDeploymentStepProperties(localStep, action)
 
-			if targetRolesInterface, ok := localStep["target_roles"]; ok {
-				var targetRoleSlice []string
+	action.Properties["Octopus.Action.Script.ScriptSource"] = "Inline"
+	action.Properties["Octopus.Action.Script.Syntax"] = localStep["script_type"].(string)
+	action.Properties["Octopus.Action.Script.ScriptBody"] = localStep["script_body"].(string)
 
-				targetRoles := targetRolesInterface.([]interface{})
+	return deploymentStep, nil
+}
 
-				for _, role := range targetRoles {
-					targetRoleSlice = append(targetRoleSlice, role.(string))
-				}
+// flattenDeploymentStepAzureScript converts a run an Azure script step into the schema
+func flattenDeploymentStepAzureScript(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
 
-				deploymentStep.Properties = map[string]string{"Octopus.Action.TargetRoles": strings.Join(targetRoleSlice, ",")}
-			}
+	flattenAzureAccountDeploymentStepProperties(action, tfStep)
+
+	tfStep["script_type"] = action.Properties["Octopus.Action.Script.Syntax"]
+	tfStep["script_body"] = action.Properties["Octopus.Action.Script.ScriptBody"]
+
+	return tfStep
+}
+
+// buildDeploymentStepHealthCheck builds a health check step
+func buildDeploymentStepHealthCheck(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.HealthCheck")
+	action := &deploymentStep.Actions[0]
+
+	includeMachinesInDeployment := "DoNotAlterMachines"
 
-			deploymentProcess.Steps = append(deploymentProcess.Steps, *deploymentStep)
+	if localStep["include_new_machines"].(bool) {
+		includeMachinesInDeployment = "IncludeCheckedMachines"
+	}
+
+	action.Properties["Octopus.Action.HealthCheck.Type"] = localStep["health_check_type"].(string)
+	action.Properties["Octopus.Action.HealthCheck.ErrorHandling"] = localStep["error_handling"].(string)
+	action.Properties["Octopus.Action.HealthCheck.IncludeMachinesInDeployment"] = includeMachinesInDeployment
+
+	return deploymentStep, nil
+}
+
+// flattenDeploymentStepHealthCheck converts a health check step into the schema
+func flattenDeploymentStepHealthCheck(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
+
+	tfStep["health_check_type"] = action.Properties["Octopus.Action.HealthCheck.Type"]
+	tfStep["error_handling"] = action.Properties["Octopus.Action.HealthCheck.ErrorHandling"]
+	tfStep["include_new_machines"] = action.Properties["Octopus.Action.HealthCheck.IncludeMachinesInDeployment"] == "IncludeCheckedMachines"
+
+	return tfStep
+}
+
+// emailRecipientProperties maps the recipient attributes of an email step to their properties
+var emailRecipientProperties = map[string]string{
+	"to":        "Octopus.Action.Email.To",
+	"to_teams":  "Octopus.Action.Email.ToTeamIds",
+	"cc":        "Octopus.Action.Email.CC",
+	"cc_teams":  "Octopus.Action.Email.CCTeamIds",
+	"bcc":       "Octopus.Action.Email.Bcc",
+	"bcc_teams": "Octopus.Action.Email.BccTeamIds",
+}
+
+// buildDeploymentStepEmail builds a send an email step
+func buildDeploymentStepEmail(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.Email")
+	action := &deploymentStep.Actions[0]
+
+	for attribute, property := range emailRecipientProperties {
+		recipients := ""
+
+		switch value := localStep[attribute].(type) {
+		case string:
+			recipients = value
+		case []interface{}:
+			recipients = strings.Join(getSliceFromTerraformTypeList(value), ",")
+		}
+
+		if recipients != "" {
+			action.Properties[property] = recipients
 		}
 	}
 
-	if v, ok := d.GetOk("deployment_step_package_script"); ok {
-		steps := v.([]interface{})
-		for _, raw := range steps {
+	if action.Properties["Octopus.Action.Email.To"] == "" && action.Properties["Octopus.Action.Email.ToTeamIds"] == "" {
+		return nil, fmt.Errorf("step %s must set at least one of to or to_teams", deploymentStep.Name)
+	}
+
+	action.Properties["Octopus.Action.Email.Subject"] = localStep["subject"].(string)
+	action.Properties["Octopus.Action.Email.Body"] = localStep["body"].(string)
+	action.Properties["Octopus.Action.Email.IsHtml"] = strconv.FormatBool(localStep["is_html"].(bool))
+	action.Properties["Octopus.Action.Email.Priority"] = localStep["priority"].(string)
 
-			localStep := raw.(map[string]interface{})
+	return deploymentStep, nil
+}
 
-			scriptFileName := localStep["script_file_name"].(string)
-			scriptParameters := localStep["script_parameters"].(string)
-			feedID := localStep["feed_id"].(string)
-			packageID := localStep["package"].(string)
-			stepCondition := localStep["step_condition"].(string)
-			stepName := localStep["step_name"].(string)
-			stepStartTrigger := localStep["step_start_trigger"].(string)
-			runOnServer := localStep["run_on_server"].(bool)
-
-			deploymentStep := &octopusdeploy.DeploymentStep{
-				Name:               stepName,
-				PackageRequirement: "LetOctopusDecide",
-				Condition:          stepCondition,
-				StartTrigger:       stepStartTrigger,
-				Actions: []octopusdeploy.DeploymentAction{
-					{
-						Name:       stepName,
-						ActionType: "Octopus.Script",
-						Properties: map[string]string{
-							"Octopus.Action.RunOnServer":                strconv.FormatBool(runOnServer),
-							"Octopus.Action.Script.ScriptSource":        "Package",
-							"Octopus.Action.Package.DownloadOnTentacle": "False",
-							"Octopus.Action.Package.FeedId":             feedID,
-							"Octopus.Action.Package.PackageId":          packageID,
-							"Octopus.Action.Script.ScriptFileName":      scriptFileName,
-							"Octopus.Action.Script.ScriptParameters":    scriptParameters,
-						},
-					},
-				},
+// flattenDeploymentStepEmail converts a send an email step into the schema
+func flattenDeploymentStepEmail(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
+
+	for attribute, property := range emailRecipientProperties {
+		if strings.HasSuffix(attribute, "_teams") {
+			if teamIDs := action.Properties[property]; teamIDs != "" {
+				tfStep[attribute] = strings.Split(teamIDs, ",")
 			}
 
-			if targetRolesInterface, ok := localStep["target_roles"]; ok {
-				var targetRoleSlice []string
+			continue
+		}
+
+		tfStep[attribute] = action.Properties[property]
+	}
 
-				targetRoles := targetRolesInterface.([]interface{})
+	tfStep["subject"] = action.Properties["Octopus.Action.Email.Subject"]
+	tfStep["body"] = action.Properties["Octopus.Action.Email.Body"]
+	tfStep["is_html"] = getBoolProperty(action.Properties, "Octopus.Action.Email.IsHtml")
+	tfStep["priority"] = "Normal"
 
-				for _, role := range targetRoles {
-					targetRoleSlice = append(targetRoleSlice, role.(string))
-				}
+	if priority := action.Properties["Octopus.Action.Email.Priority"]; priority != "" {
+		tfStep["priority"] = priority
+	}
+
+	return tfStep
+}
+
+// buildDeploymentStepDeployRelease builds a deploy a release step
+func buildDeploymentStepDeployRelease(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error) {
+	deploymentStep := buildStandardDeploymentStep(localStep, "Octopus.DeployRelease")
+	action := &deploymentStep.Actions[0]
+	projectID := localStep["project_id"].(string)
+
+	variables, err := json.Marshal(localStep["variables"].(map[string]interface{}))
 
-				deploymentStep.Properties = map[string]string{"Octopus.Action.TargetRoles": strings.Join(targetRoleSlice, ",")}
+	if err != nil {
+		return nil, fmt.Errorf("error building variables of step %s: %s", deploymentStep.Name, err.Error())
+	}
+
+	// the release to deploy is chosen like a package version, from the releases of the project
+	action.Properties["Octopus.Action.Package.FeedId"] = "feeds-builtin-releases"
+	action.Properties["Octopus.Action.Package.PackageId"] = projectID
+	action.Properties["Octopus.Action.DeployRelease.ProjectId"] = projectID
+	action.Properties["Octopus.Action.DeployRelease.DeploymentCondition"] = localStep["deployment_condition"].(string)
+	action.Properties["Octopus.Action.DeployRelease.Variables"] = string(variables)
+
+	return deploymentStep, nil
+}
+
+// flattenDeploymentStepDeployRelease converts a deploy a release step into the schema
+func flattenDeploymentStepDeployRelease(step octopusdeploy.DeploymentStep) map[string]interface{} {
+	tfStep := flattenStandardDeploymentStep(step)
+	action := step.Actions[0]
+
+	tfStep["project_id"] = action.Properties["Octopus.Action.DeployRelease.ProjectId"]
+	tfStep["deployment_condition"] = action.Properties["Octopus.Action.DeployRelease.DeploymentCondition"]
+
+	var variables map[string]string
+
+	if err := json.Unmarshal([]byte(action.Properties["Octopus.Action.DeployRelease.Variables"]), &variables); err == nil {
+		tfStep["variables"] = variables
+	}
+
+	return tfStep
+}
+
+// deploymentStepType is a deployment step type which is built from its schema attribute and read back into it
+type deploymentStepType struct {
+	attribute   string
+	actionType  string
+	requireRole bool
+	schema      func() *schema.Schema
+	build       func(localStep map[string]interface{}) (*octopusdeploy.DeploymentStep, error)
+	flatten     func(step octopusdeploy.DeploymentStep) map[string]interface{}
+	// sensitiveAttributes can't be read back, so they are kept from the state of the step with the same name
+	sensitiveAttributes []string
+	// match tells apart the actions of step types sharing an action type
+	match func(action octopusdeploy.DeploymentAction) bool
+}
+
+// deploymentStepTypes are the deployment step types, in the order their steps are added to the deployment process
+var deploymentStepTypes = []deploymentStepType{
+	{
+		attribute:   "deployment_step_windows_service",
+		schema:      getDeploymentStepWindowsServiceSchema,
+		actionType:  "Octopus.WindowsService",
+		requireRole: true,
+		build:       buildDeploymentStepWindowsService,
+		flatten:     flattenDeploymentStepWindowsService,
+		sensitiveAttributes: []string{
+			"custom_account_password",
+		},
+	},
+	{
+		attribute:   "deployment_step_iis_website",
+		schema:      getDeploymentStepIISWebsiteSchema,
+		actionType:  "Octopus.IIS",
+		requireRole: true,
+		build:       buildDeploymentStepIISWebsite,
+		flatten:     flattenDeploymentStepIISWebsite,
+	},
+	{
+		attribute:  "deployment_step_inline_script",
+		schema:     getDeploymentStepInlineScriptSchema,
+		actionType: "Octopus.Script",
+		build:      buildDeploymentStepInlineScript,
+		flatten:    flattenDeploymentStepInlineScript,
+		match: func(action octopusdeploy.DeploymentAction) bool {
+			return action.Properties["Octopus.Action.Script.ScriptSource"] == "Inline"
+		},
+	},
+	{
+		attribute:  "deployment_step_package_script",
+		schema:     getDeploymentStepPackageScriptSchema,
+		actionType: "Octopus.Script",
+		build:      buildDeploymentStepPackageScript,
+		flatten:    flattenDeploymentStepPackageScript,
+		match: func(action octopusdeploy.DeploymentAction) bool {
+			return action.Properties["Octopus.Action.Script.ScriptSource"] == "Package"
+		},
+	},
+	{
+		attribute:   "deployment_step_package_extract",
+		schema:      getDeploymentStepPackageExtractSchema,
+		actionType:  "Octopus.TentaclePackage",
+		requireRole: true,
+		build:       buildDeploymentStepPackageExtract,
+		flatten:     flattenDeploymentStepPackageExtract,
+	},
+	{
+		attribute:  "deployment_step_manual_intervention",
+		schema:     getDeploymentStepManualInterventionSchema,
+		actionType: "Octopus.Manual",
+		build:      buildDeploymentStepManualIntervention,
+		flatten:    flattenDeploymentStepManualIntervention,
+	},
+	{
+		attribute:   "deployment_step_kubernetes_containers",
+		schema:      getDeploymentStepKubernetesContainersSchema,
+		actionType:  "Octopus.KubernetesDeployContainers",
+		requireRole: true,
+		build:       buildDeploymentStepKubernetesContainers,
+		flatten:     flattenDeploymentStepKubernetesContainers,
+	},
+	{
+		attribute:   "deployment_step_kubernetes_raw_yaml",
+		schema:      getDeploymentStepKubernetesRawYamlSchema,
+		actionType:  "Octopus.KubernetesDeployRawYaml",
+		requireRole: true,
+		build:       buildDeploymentStepKubernetesRawYaml,
+		flatten:     flattenDeploymentStepKubernetesRawYaml,
+	},
+	{
+		attribute:   "deployment_step_helm_chart_upgrade",
+		schema:      getDeploymentStepHelmChartUpgradeSchema,
+		actionType:  "Octopus.HelmChartUpgrade",
+		requireRole: true,
+		build:       buildDeploymentStepHelmChartUpgrade,
+		flatten:     flattenDeploymentStepHelmChartUpgrade,
+	},
+	{
+		attribute:   "deployment_step_kubectl_script",
+		schema:      getDeploymentStepKubectlScriptSchema,
+		actionType:  "Octopus.KubernetesRunScript",
+		requireRole: true,
+		build:       buildDeploymentStepKubectlScript,
+		flatten:     flattenDeploymentStepKubectlScript,
+	},
+	{
+		attribute:  "deployment_step_aws_cloudformation",
+		schema:     getDeploymentStepAwsCloudFormationSchema,
+		actionType: "Octopus.AwsRunCloudFormation",
+		build:      buildDeploymentStepAwsCloudFormation,
+		flatten:    flattenDeploymentStepAwsCloudFormation,
+	},
+	{
+		attribute:  "deployment_step_aws_s3_upload",
+		schema:     getDeploymentStepAwsS3UploadSchema,
+		actionType: "Octopus.AwsUploadS3",
+		build:      buildDeploymentStepAwsS3Upload,
+		flatten:    flattenDeploymentStepAwsS3Upload,
+	},
+	{
+		attribute:  "deployment_step_aws_cli_script",
+		schema:     getDeploymentStepAwsCliScriptSchema,
+		actionType: "Octopus.AwsRunScript",
+		build:      buildDeploymentStepAwsCliScript,
+		flatten:    flattenDeploymentStepAwsCliScript,
+	},
+	{
+		attribute:  "deployment_step_azure_app_service",
+		schema:     getDeploymentStepAzureAppServiceSchema,
+		actionType: "Octopus.AzureWebApp",
+		build:      buildDeploymentStepAzureAppService,
+		flatten:    flattenDeploymentStepAzureAppService,
+	},
+	{
+		attribute:  "deployment_step_azure_resource_group",
+		schema:     getDeploymentStepAzureResourceGroupSchema,
+		actionType: "Octopus.AzureResourceGroup",
+		build:      buildDeploymentStepAzureResourceGroup,
+		flatten:    flattenDeploymentStepAzureResourceGroup,
+	},
+	{
+		attribute:  "deployment_step_azure_script",
+		schema:     getDeploymentStepAzureScriptSchema,
+		actionType: "Octopus.AzurePowerShell",
+		build:      buildDeploymentStepAzureScript,
+		flatten:    flattenDeploymentStepAzureScript,
+	},
+	{
+		attribute:   "deployment_step_health_check",
+		schema:      getDeploymentStepHealthCheckSchema,
+		actionType:  "Octopus.HealthCheck",
+		requireRole: true,
+		build:       buildDeploymentStepHealthCheck,
+		flatten:     flattenDeploymentStepHealthCheck,
+	},
+	{
+		attribute:  "deployment_step_email",
+		schema:     getDeploymentStepEmailSchema,
+		actionType: "Octopus.Email",
+		build:      buildDeploymentStepEmail,
+		flatten:    flattenDeploymentStepEmail,
+	},
+	{
+		attribute:  "deployment_step_deploy_release",
+		schema:     getDeploymentStepDeployReleaseSchema,
+		actionType: "Octopus.DeployRelease",
+		build:      buildDeploymentStepDeployRelease,
+		flatten:    flattenDeploymentStepDeployRelease,
+	},
+}
+
+// addDeploymentStepsSchema adds an attribute for each deployment step type to the schema of a resource with a process
+func addDeploymentStepsSchema(resourceSchema map[string]*schema.Schema) {
+	for _, stepType := range deploymentStepTypes {
+		resourceSchema[stepType.attribute] = stepType.schema()
+	}
+}
+
+// keepSensitiveDeploymentStepAttributes copies the sensitive attributes of the steps of a type from the state, as
+// Octopus never returns sensitive values
+func keepSensitiveDeploymentStepAttributes(d *schema.ResourceData, stepType deploymentStepType, tfSteps []interface{}) {
+	stateSteps := make(map[string]map[string]interface{})
+
+	for _, raw := range d.Get(stepType.attribute).([]interface{}) {
+		stateStep := raw.(map[string]interface{})
+		stateSteps[stateStep["step_name"].(string)] = stateStep
+	}
+
+	for _, raw := range tfSteps {
+		tfStep := raw.(map[string]interface{})
+
+		if stateStep, ok := stateSteps[tfStep["step_name"].(string)]; ok {
+			for _, attribute := range stepType.sensitiveAttributes {
+				tfStep[attribute] = stateStep[attribute]
 			}
+		}
+	}
+}
 
-			deploymentProcess.Steps = append(deploymentProcess.Steps, *deploymentStep)
+// hasOrderedDeploymentSteps returns whether the steps of the process set order
+func hasOrderedDeploymentSteps(d *schema.ResourceData) bool {
+	for _, stepType := range deploymentStepTypes {
+		for _, raw := range d.Get(stepType.attribute).([]interface{}) {
+			if raw.(map[string]interface{})["order"].(int) != 0 {
+				return true
+			}
 		}
 	}
 
-	if v, ok := d.GetOk("deployment_step_package_extract"); ok {
-		steps := v.([]interface{})
-		for _, raw := range steps {
+	return false
+}
+
+// flattenDeploymentSteps sets the deployment steps from the steps of a deployment or runbook process. Every action
+// after the first of a step is read back as a child step of it. The order of the steps is only read back when the
+// steps set order, so reordering them in Octopus shows in the plan.
+func flattenDeploymentSteps(d *schema.ResourceData, deploymentSteps []octopusdeploy.DeploymentStep) {
+	tfSteps := make(map[string][]interface{})
+	ordered := hasOrderedDeploymentSteps(d)
+
+	for stepIndex, step := range deploymentSteps {
+		for i, action := range step.Actions {
+			for _, stepType := range deploymentStepTypes {
+				if action.ActionType != stepType.actionType {
+					continue
+				}
 
-			localStep := raw.(map[string]interface{})
+				if stepType.match != nil && !stepType.match(action) {
+					continue
+				}
 
+				if i == 0 {
+					tfStep := stepType.flatten(step)
 
-			runOnServer := localStep["run_on_server"].(bool)
-			configurationTransforms := localStep["configuration_transforms"].(bool)
-			configurationVariables := localStep["configuration_variables"].(bool)
-			downloadOnTentacle = localStep["download_on_tentacle"].(bool)
-			feedID := localStep["feed_id"].(string)
-			packageID := localStep["package"].(string)
-			substituteEnabled = localStep["substitute_enabled"].(bool)
-			substituteTargets = localStep["substitute_targets"].(string)
-
-			deploymentStep := &octopusdeploy.DeploymentStep{
-				Name:               stepName,
-				PackageRequirement: "LetOctopusDecide",
-				Condition:          stepCondition,
-				StartTrigger:       stepStartTrigger,
-				Actions: []octopusdeploy.DeploymentAction{
-					{
-						Name:       stepName,
-						ActionType: "Octopus.TentaclePackage",
-						Properties: map[string]string{
-							"Octopus.Action.RunOnServer":                									strconv.FormatBool(runOnServer),
-							"Octopus.Action.EnabledFeatures":												"Octopus.Features.WindowsService,Octopus.Features.ConfigurationTransforms,Octopus.Features.ConfigurationVariables"
-							"Octopus.Action.Package.AutomaticallyRunConfigurationTransformationFiles": 		strconv.FormatBool(configurationTransforms),
-							"Octopus.Action.Package.AutomaticallyUpdateAppSettingsAndConnectionStrings":	strconv.FormatBool(configurationVariables),
-							"Octopus.Action.Package.DownloadOnTentacle": 									"False",
-							"Octopus.Action.Package.FeedId":             									feedID,
-							"Octopus.Action.Package.PackageId":          									packageID,
-							"Octopus.Action.SubstituteInFiles.Enabled":      								strconv.FormatBool(substituteEnabled)
-							"Octopus.Action.SubstituteInFiles.TargetFiles":									substituteTargets
-						},
-					},
-				},
-			}
+					if ordered {
+						tfStep["order"] = stepIndex + 1
+					}
 
-			if targetRolesInterface, ok := localStep["target_roles"]; ok {
-				var targetRoleSlice []string
+					tfSteps[stepType.attribute] = append(tfSteps[stepType.attribute], tfStep)
+					break
+				}
 
-				targetRoles := targetRolesInterface.([]interface{})
+				// child steps only have an action, so they are read back with the step settings of their parent step,
+				// other than target_roles and window_size, which child steps cannot set
+				childStep := octopusdeploy.DeploymentStep{
+					Name:               action.Name,
+					PackageRequirement: step.PackageRequirement,
+					Condition:          step.Condition,
+					StartTrigger:       step.StartTrigger,
+					Properties:         map[string]string{},
+					Actions:            []octopusdeploy.DeploymentAction{action},
+				}
 
-				for _, role := range targetRoles {
-					targetRoleSlice = append(targetRoleSlice, role.(string))
+				if conditionExpression := step.Properties["Octopus.Action.ConditionVariableExpression"]; conditionExpression != "" {
+					childStep.Properties["Octopus.Action.ConditionVariableExpression"] = conditionExpression
 				}
 
-				deploymentStep.Properties = map[string]string{"Octopus.Action.TargetRoles": strings.Join(targetRoleSlice, ",")}
-			}
+				tfStep := stepType.flatten(childStep)
+				tfStep["parent_step"] = step.Name
+
+				if ordered {
+					tfStep["order"] = i
+				}
 
-			deploymentProcess.Steps = append(deploymentProcess.Steps, *deploymentStep)
+				tfSteps[stepType.attribute] = append(tfSteps[stepType.attribute], tfStep)
+				break
+			}
 		}
 	}
 
-	return deploymentProcess
+	for _, stepType := range deploymentStepTypes {
+		if len(stepType.sensitiveAttributes) > 0 {
+			keepSensitiveDeploymentStepAttributes(d, stepType, tfSteps[stepType.attribute])
+		}
+
+		d.Set(stepType.attribute, tfSteps[stepType.attribute])
+	}
 }
 
-func buildProjectResource(d *schema.ResourceData) *octopusdeploy.Project {
+func buildProjectResource(d *schema.ResourceData) (*octopusdeploy.Project, error) {
 	name := d.Get("name").(string)
 	lifecycleID := d.Get("lifecycle_id").(string)
 	projectGroupID := d.Get("project_group_id").(string)
 
 	project := octopusdeploy.NewProject(name, lifecycleID, projectGroupID)
 
-	if attr, ok := d.GetOk("description"); ok {
-		project.Description = attr.(string)
+	if err := setProjectValues(d, project); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// setProjectValues copies the values managed by Terraform onto a project. Fields that are not part of the
+// schema are left alone, so a project fetched from Octopus can be updated without resetting them to defaults.
+func setProjectValues(d *schema.ResourceData, project *octopusdeploy.Project) error {
+	project.Name = d.Get("name").(string)
+	project.LifecycleID = d.Get("lifecycle_id").(string)
+	project.ProjectGroupID = d.Get("project_group_id").(string)
+	project.Description = d.Get("description").(string)
+	project.DefaultGuidedFailureMode = d.Get("default_failure_mode").(string)
+	project.ProjectConnectivityPolicy.SkipMachineBehavior = d.Get("skip_machine_behavior").(string)
+	project.ProjectConnectivityPolicy.AllowDeploymentsToNoTargets = d.Get("allow_deployments_to_no_targets").(bool)
+	project.AutoCreateRelease = d.Get("auto_create_release").(bool)
+	project.DefaultToSkipIfAlreadyInstalled = d.Get("default_to_skip_if_already_installed").(bool)
+	project.DiscreteChannelRelease = d.Get("discrete_channel_release").(bool)
+	project.IsDisabled = d.Get("is_disabled").(bool)
+	project.TenantedDeploymentMode = d.Get("tenanted_deployment_mode").(string)
+
+	project.IncludedLibraryVariableSetIds = emptyIfNil(getSliceFromTerraformTypeList(d.Get("included_library_variable_set_ids")))
+
+	// an omitted release_creation_strategy clears the strategy, so it can be unset as well as set
+	project.ReleaseCreationStrategy = octopusdeploy.ReleaseCreationStrategy{}
+
+	if tfReleaseCreationStrategies := d.Get("release_creation_strategy").([]interface{}); len(tfReleaseCreationStrategies) > 0 && tfReleaseCreationStrategies[0] != nil {
+		tfReleaseCreationStrategy := tfReleaseCreationStrategies[0].(map[string]interface{})
+
+		project.ReleaseCreationStrategy = octopusdeploy.ReleaseCreationStrategy{
+			ChannelID:                    tfReleaseCreationStrategy["channel_id"].(string),
+			ReleaseCreationPackageStepID: tfReleaseCreationStrategy["release_creation_package_step_id"].(string),
+		}
+	}
+
+	templates, err := buildProjectTemplates(d, project.Templates)
+
+	if err != nil {
+		return err
+	}
+
+	project.Templates = templates
+
+	if attr, ok := d.GetOk("versioning_strategy"); ok {
+		tfVersioningStrategy := attr.([]interface{})[0].(map[string]interface{})
+
+		template := tfVersioningStrategy["template"].(string)
+		donorPackageStepID := tfVersioningStrategy["donor_package_step_id"].(string)
+
+		// need to validate here as ConflictsWith cannot be used inside a schema.TypeList
+		if (template == "") == (donorPackageStepID == "") {
+			return fmt.Errorf("versioning_strategy must set exactly one of template or donor_package_step_id")
+		}
+
+		project.VersioningStrategy = octopusdeploy.VersioningStrategy{
+			DonorPackageStepID: donorPackageStepID,
+			Template:           template,
+		}
+	}
+
+	return nil
+}
+
+// matchProjectTemplateIDs returns the ID of the existing template each template block is for, or an empty string for
+// new templates. Blocks are matched on their id, so renaming a template keeps its ID and the tenant variable values
+// stored against it. As ids are held by position in the list, a block whose id belongs to a template of another
+// name is only matched on it when no block has that name, so inserting a template does not take over the ID of the
+// one after it. Blocks without an id fall back to matching on name.
+func matchProjectTemplateIDs(tfTemplates []interface{}, existingTemplates []octopusdeploy.ActionTemplateParameter) []string {
+	existingNames := make(map[string]string)
+	existingIDs := make(map[string]string)
+
+	for _, existingTemplate := range existingTemplates {
+		existingNames[existingTemplate.ID] = existingTemplate.Name
+		existingIDs[existingTemplate.Name] = existingTemplate.ID
+	}
+
+	templateIDs := make([]string, len(tfTemplates))
+	claimed := make(map[string]bool)
+
+	claim := func(i int, templateID string) {
+		if templateIDs[i] == "" && templateID != "" && !claimed[templateID] {
+			templateIDs[i] = templateID
+			claimed[templateID] = true
+		}
+	}
+
+	// blocks whose id and name both match an existing template
+	for i, raw := range tfTemplates {
+		tfTemplate := raw.(map[string]interface{})
+		templateID := tfTemplate["id"].(string)
+
+		if name, ok := existingNames[templateID]; ok && name == tfTemplate["name"].(string) {
+			claim(i, templateID)
+		}
+	}
+
+	// blocks without an id, or whose id has moved to another template, matched by name
+	for i, raw := range tfTemplates {
+		claim(i, existingIDs[raw.(map[string]interface{})["name"].(string)])
+	}
+
+	// renamed templates
+	for i, raw := range tfTemplates {
+		templateID := raw.(map[string]interface{})["id"].(string)
+
+		if _, ok := existingNames[templateID]; ok {
+			claim(i, templateID)
+		}
+	}
+
+	return templateIDs
+}
+
+// buildProjectTemplates builds the project templates from the schema. IDs of existing templates are reused, as
+// tenant variable values are stored against the template ID.
+func buildProjectTemplates(d *schema.ResourceData, existingTemplates []octopusdeploy.ActionTemplateParameter) ([]octopusdeploy.ActionTemplateParameter, error) {
+	tfTemplates := d.Get("template").([]interface{})
+	templateIDs := matchProjectTemplateIDs(tfTemplates, existingTemplates)
+
+	templates := []octopusdeploy.ActionTemplateParameter{}
+
+	for i, raw := range tfTemplates {
+		tfTemplate := raw.(map[string]interface{})
+
+		name := tfTemplate["name"].(string)
+		controlType := tfTemplate["control_type"].(string)
+		defaultValue := tfTemplate["default_value"].(string)
+		defaultSensitiveValue := tfTemplate["default_sensitive_value"].(string)
+		selectOptions := tfTemplate["select_option"].([]interface{})
+
+		templateID := templateIDs[i]
+
+		if templateID == "" {
+			newID, err := uuid.GenerateUUID()
+
+			if err != nil {
+				return nil, fmt.Errorf("error generating id for template %s: %s", name, err.Error())
+			}
+
+			templateID = newID
+		}
+
+		template := octopusdeploy.ActionTemplateParameter{
+			ID:       templateID,
+			Name:     name,
+			Label:    tfTemplate["label"].(string),
+			HelpText: tfTemplate["help_text"].(string),
+			DisplaySettings: map[string]string{
+				"Octopus.ControlType": controlType,
+			},
+		}
+
+		if controlType == "Sensitive" {
+			if defaultValue != "" {
+				return nil, fmt.Errorf("template %s must use default_sensitive_value rather than default_value as its control_type is Sensitive", name)
+			}
+
+			template.DefaultValue = octopusdeploy.NewPropertyValue(defaultSensitiveValue, true)
+		} else {
+			if defaultSensitiveValue != "" {
+				return nil, fmt.Errorf("template %s can only use default_sensitive_value when its control_type is Sensitive", name)
+			}
+
+			template.DefaultValue = octopusdeploy.NewPropertyValue(defaultValue, false)
+		}
+
+		if controlType == "Select" {
+			if len(selectOptions) == 0 {
+				return nil, fmt.Errorf("template %s must have at least one select_option as its control_type is Select", name)
+			}
+
+			var options []string
+
+			for _, rawOption := range selectOptions {
+				option := rawOption.(map[string]interface{})
+				options = append(options, fmt.Sprintf("%s|%s", option["value"].(string), option["display_name"].(string)))
+			}
+
+			template.DisplaySettings["Octopus.SelectOptions"] = strings.Join(options, "\n")
+		} else if len(selectOptions) > 0 {
+			return nil, fmt.Errorf("template %s can only have select_option blocks when its control_type is Select", name)
+		}
+
+		templates = append(templates, template)
 	}
 
-	if attr, ok := d.GetOk("default_failure_mode"); ok {
-		project.DefaultGuidedFailureMode = attr.(string)
+	return templates, nil
+}
+
+// flattenProjectTemplates converts project templates into the schema. Sensitive default values are never returned
+// by Octopus, so they are kept from the current state.
+func flattenProjectTemplates(d *schema.ResourceData, templates []octopusdeploy.ActionTemplateParameter) []interface{} {
+	sensitiveValues := make(map[string]string)
+
+	for _, raw := range d.Get("template").([]interface{}) {
+		tfTemplate := raw.(map[string]interface{})
+		sensitiveValues[tfTemplate["name"].(string)] = tfTemplate["default_sensitive_value"].(string)
 	}
 
-	if attr, ok := d.GetOk("skip_machine_behavior"); ok {
-		project.ProjectConnectivityPolicy.SkipMachineBehavior = attr.(string)
+	var tfTemplates []interface{}
+
+	for _, template := range templates {
+		tfTemplate := map[string]interface{}{
+			"id":           template.ID,
+			"name":         template.Name,
+			"label":        template.Label,
+			"help_text":    template.HelpText,
+			"control_type": template.DisplaySettings["Octopus.ControlType"],
+		}
+
+		if template.DefaultValue != nil {
+			if template.DefaultValue.IsSensitive {
+				tfTemplate["default_sensitive_value"] = sensitiveValues[template.Name]
+			} else {
+				tfTemplate["default_value"] = template.DefaultValue.Value
+			}
+		}
+
+		var selectOptions []interface{}
+
+		if rawOptions := template.DisplaySettings["Octopus.SelectOptions"]; rawOptions != "" {
+			for _, rawOption := range strings.Split(rawOptions, "\n") {
+				optionParts := strings.SplitN(strings.TrimSpace(rawOption), "|", 2)
+
+				option := map[string]interface{}{
+					"value":        optionParts[0],
+					"display_name": optionParts[0],
+				}
+
+				if len(optionParts) == 2 {
+					option["display_name"] = optionParts[1]
+				}
+
+				selectOptions = append(selectOptions, option)
+			}
+		}
+
+		tfTemplate["select_option"] = selectOptions
+		tfTemplates = append(tfTemplates, tfTemplate)
 	}
 
-	return project
+	return tfTemplates
 }
 
 func updateDeploymentProcess(d *schema.ResourceData, client *octopusdeploy.Client, projectID string) error {
@@ -745,11 +3929,13 @@ func updateDeploymentProcess(d *schema.ResourceData, client *octopusdeploy.Clien
 		return fmt.Errorf("error getting deployment process for project: %s", err.Error())
 	}
 
-	newDeploymentProcess := buildDeploymentProcess(d, deploymentProcess)
-	// set the newly build deployment processes ID so it can be updated
-	newDeploymentProcess.ID = deploymentProcess.ID
+	deploymentProcess.Steps, err = buildDeploymentSteps(d)
+
+	if err != nil {
+		return err
+	}
 
-	updateDeploymentProcess, err := client.DeploymentProcess.Update(newDeploymentProcess)
+	updateDeploymentProcess, err := client.DeploymentProcess.Update(deploymentProcess)
 
 	if err != nil {
 		return fmt.Errorf("error creating deployment process for project: %s", err.Error())
@@ -763,7 +3949,11 @@ func updateDeploymentProcess(d *schema.ResourceData, client *octopusdeploy.Clien
 func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*octopusdeploy.Client)
 
-	newProject := buildProjectResource(d)
+	newProject, err := buildProjectResource(d)
+
+	if err != nil {
+		return err
+	}
 
 	createdProject, err := client.Project.Add(newProject)
 
@@ -807,17 +3997,73 @@ func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("project_group_id", project.ProjectGroupID)
 	d.Set("default_failure_mode", project.DefaultGuidedFailureMode)
 	d.Set("skip_machine_behavior", project.ProjectConnectivityPolicy.SkipMachineBehavior)
+	d.Set("allow_deployments_to_no_targets", project.ProjectConnectivityPolicy.AllowDeploymentsToNoTargets)
+	d.Set("auto_create_release", project.AutoCreateRelease)
+	d.Set("default_to_skip_if_already_installed", project.DefaultToSkipIfAlreadyInstalled)
+	d.Set("discrete_channel_release", project.DiscreteChannelRelease)
+	d.Set("is_disabled", project.IsDisabled)
+	d.Set("tenanted_deployment_mode", project.TenantedDeploymentMode)
+	d.Set("included_library_variable_set_ids", project.IncludedLibraryVariableSetIds)
+	d.Set("deployment_process_id", project.DeploymentProcessID)
+
+	var releaseCreationStrategy []interface{}
+
+	if project.ReleaseCreationStrategy != (octopusdeploy.ReleaseCreationStrategy{}) {
+		releaseCreationStrategy = append(releaseCreationStrategy, map[string]interface{}{
+			"channel_id":                       project.ReleaseCreationStrategy.ChannelID,
+			"release_creation_package_step_id": project.ReleaseCreationStrategy.ReleaseCreationPackageStepID,
+		})
+	}
+
+	d.Set("release_creation_strategy", releaseCreationStrategy)
+
+	d.Set("versioning_strategy", []interface{}{
+		map[string]interface{}{
+			"template":              project.VersioningStrategy.Template,
+			"donor_package_step_id": project.VersioningStrategy.DonorPackageStepID,
+		},
+	})
+
+	d.Set("template", flattenProjectTemplates(d, project.Templates))
+
+	deploymentProcess, err := client.DeploymentProcess.Get(project.DeploymentProcessID)
+
+	if err != nil {
+		return fmt.Errorf("error reading deployment process id %s: %s", project.DeploymentProcessID, err.Error())
+	}
+
+	flattenDeploymentSteps(d, deploymentProcess.Steps)
+
+	var autoDeployReleaseOverrides []interface{}
+
+	for _, override := range project.AutoDeployReleaseOverrides {
+		autoDeployReleaseOverrides = append(autoDeployReleaseOverrides, map[string]interface{}{
+			"environment_id": override.EnvironmentID,
+			"release_id":     override.ReleaseID,
+			"tenant_id":      override.TenantID,
+		})
+	}
+
+	d.Set("auto_deploy_release_overrides", autoDeployReleaseOverrides)
 
 	return nil
 }
 
 func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
-	project := buildProjectResource(d)
-	project.ID = d.Id() // set project struct ID so octopus knows which project to update
-
 	client := m.(*octopusdeploy.Client)
 
-	project, err := client.Project.Update(project)
+	// start from the project as it is in Octopus, so values Terraform does not manage are sent back unchanged
+	project, err := client.Project.Get(d.Id())
+
+	if err != nil {
+		return fmt.Errorf("error reading project id %s: %s", d.Id(), err.Error())
+	}
+
+	if err := setProjectValues(d, project); err != nil {
+		return err
+	}
+
+	project, err = client.Project.Update(project)
 
 	if err != nil {
 		return fmt.Errorf("error updating project id %s: %s", d.Id(), err.Error())
@@ -79,13 +79,7 @@ func buildProjectDeploymentTargetTriggerResource(d *schema.ResourceData) (*octop
 		eventGroups := getSliceFromTerraformTypeList(attr)
 
 		// need to validate here "ValidateFunc is not yet supported on lists or sets."
-		validValues := []string{
-			"Machine",
-			"MachineCritical",
-			"MachineAvailableForDeployment",
-			"MachineUnavailableForDeployment",
-			"MachineHealthChanged",
-		}
+		validValues := octopusdeploy.ValidMachineEventGroups
 
 		if invalidValue, ok := validateAllSliceItemsInSlice(eventGroups, validValues); !ok {
 			return nil, fmt.Errorf("Invalid value for event_groups. %s not in %v", invalidValue, validValues)
@@ -98,17 +92,7 @@ func buildProjectDeploymentTargetTriggerResource(d *schema.ResourceData) (*octop
 		eventCategories := getSliceFromTerraformTypeList(attr)
 
 		// need to validate here "ValidateFunc is not yet supported on lists or sets."
-		validValues := []string{
-			"MachineCleanupFailed",
-			"MachineAdded",
-			"MachineDeploymentRelatedPropertyWasUpdated",
-			"MachineDisabled",
-			"MachineEnabled",
-			"MachineHealthy",
-			"MachineUnavailable",
-			"MachineUnhealthy",
-			"MachineHasWarnings",
-		}
+		validValues := octopusdeploy.ValidMachineEventCategories
 
 		if invalidValue, ok := validateAllSliceItemsInSlice(eventCategories, validValues); !ok {
 			return nil, fmt.Errorf("Invalid value for event_categories. %s not in %v", invalidValue, validValues)
@@ -24,6 +24,20 @@ func resourceProjectGroup() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"environment_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The IDs of the environments the projects of the group can be deployed to. Empty allows every environment.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"retention_policy_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the retention policy of the project group.",
+			},
 		},
 	}
 }
@@ -37,6 +51,12 @@ func buildProjectGroupResource(d *schema.ResourceData) *octopusdeploy.ProjectGro
 		projectGroup.Description = attr.(string)
 	}
 
+	projectGroup.EnvironmentIds = emptyIfNil(getSliceFromTerraformTypeList(d.Get("environment_ids")))
+
+	if attr, ok := d.GetOk("retention_policy_id"); ok {
+		projectGroup.RetentionPolicyID = attr.(string)
+	}
+
 	return projectGroup
 }
 
@@ -68,12 +88,14 @@ func resourceProjectGroupRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err != nil {
-		return fmt.Errorf("error reading projectgroup id %s: %s", projectGroup.ID, err.Error())
+		return fmt.Errorf("error reading projectgroup id %s: %s", projectGroupID, err.Error())
 	}
 
 	log.Printf("[DEBUG] projectgroup: %v", m)
 	d.Set("name", projectGroup.Name)
 	d.Set("description", projectGroup.Description)
+	d.Set("environment_ids", projectGroup.EnvironmentIds)
+	d.Set("retention_policy_id", projectGroup.RetentionPolicyID)
 	return nil
 }
 
@@ -73,6 +73,43 @@ func TestAccOctopusDeployProjectGroupWithUpdate(t *testing.T) {
 	})
 }
 
+func TestAccOctopusDeployProjectGroupWithEnvironments(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project_group.foo"
+	const dataNamePrefix = "data.octopusdeploy_project_group.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectGroupWithEnvironments,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectGroupExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "environment_ids.0", "Environments-1"),
+					resource.TestCheckResourceAttrSet(
+						terraformNamePrefix, "retention_policy_id"),
+					resource.TestCheckResourceAttrPair(
+						dataNamePrefix, "id", terraformNamePrefix, "id"),
+					resource.TestCheckResourceAttr(
+						dataNamePrefix, "environment_ids.0", "Environments-1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccProjectGroupWithEnvironments = `
+		resource "octopusdeploy_project_group" "foo" {
+			name            = "Funky Group"
+			environment_ids = ["Environments-1"]
+		}
+
+		data "octopusdeploy_project_group" "foo" {
+			name = "${octopusdeploy_project_group.foo.name}"
+		}
+		`
+
 func testAccProjectGroupBasic(name string) string {
 	return fmt.Sprintf(`
 		resource "octopusdeploy_project_group" "foo" {
@@ -114,6 +151,10 @@ func testAccCheckOctopusDeployProjectGroupExists(n string) resource.TestCheckFun
 
 func destroyHelperProjectGroup(s *terraform.State, client *octopusdeploy.Client) error {
 	for _, r := range s.RootModule().Resources {
+		if r.Type != "octopusdeploy_project_group" {
+			continue
+		}
+
 		if _, err := client.ProjectGroup.Get(r.Primary.ID); err != nil {
 			if err == octopusdeploy.ErrItemNotFound {
 				continue
@@ -127,6 +168,10 @@ func destroyHelperProjectGroup(s *terraform.State, client *octopusdeploy.Client)
 
 func existsHelperProjectGroup(s *terraform.State, client *octopusdeploy.Client) error {
 	for _, r := range s.RootModule().Resources {
+		if r.Type != "octopusdeploy_project_group" {
+			continue
+		}
+
 		if _, err := client.ProjectGroup.Get(r.Primary.ID); err != nil {
 			return fmt.Errorf("received an error retrieving projectgroup %s", err)
 		}
@@ -220,6 +220,780 @@ func TestAccOctopusDeployProjectWithUpdate(t *testing.T) {
 	})
 }
 
+func TestAccOctopusDeployProjectWithProjectSettings(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project.foo"
+	const projectName = "Funky Monkey"
+	const lifeCycleID = "Lifecycles-1"
+	const projectGroupID = "ProjectGroups-1"
+	const versionTemplate = "1.0.#{Octopus.Version.NextPatch}"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectWithProjectSettings(projectName, lifeCycleID, projectGroupID, versionTemplate),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "allow_deployments_to_no_targets", "true"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "default_to_skip_if_already_installed", "true"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "discrete_channel_release", "true"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "is_disabled", "true"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "tenanted_deployment_mode", "TenantedOrUntenanted"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "versioning_strategy.0.template", versionTemplate),
+				),
+			},
+			// removing the settings resets them, but leaves the versioning strategy as it is in Octopus
+			{
+				Config: testAccProjectBasic(projectName, lifeCycleID, projectGroupID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "allow_deployments_to_no_targets", "false"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "is_disabled", "false"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "tenanted_deployment_mode", "Untenanted"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "versioning_strategy.0.template", versionTemplate),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithTemplates(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project.foo"
+	var templateID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWithTemplates("Tenant.Database.Name"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					testAccCheckOctopusDeployProjectTemplateID(terraformNamePrefix, &templateID),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "template.#", "3"),
+					resource.TestCheckResourceAttrSet(
+						terraformNamePrefix, "template.0.id"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "template.0.name", "Tenant.Database.Name"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "template.0.default_value", "billing"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "template.1.control_type", "Sensitive"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "template.1.default_sensitive_value", "hunter2"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "template.2.select_option.#", "2"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "template.2.select_option.1.display_name", "Europe"),
+				),
+			},
+			// renaming a template keeps its ID, so tenant values are not orphaned
+			{
+				Config: testAccWithTemplates("Tenant.Database.Catalog"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					testAccCheckOctopusDeployProjectTemplateID(terraformNamePrefix, &templateID),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "template.0.name", "Tenant.Database.Catalog"),
+				),
+			},
+		},
+	})
+}
+
+func TestMatchProjectTemplateIDs(t *testing.T) {
+	existingTemplates := []octopusdeploy.ActionTemplateParameter{
+		{ID: "a", Name: "A"},
+		{ID: "b", Name: "B"},
+	}
+
+	tfTemplate := func(id, name string) interface{} {
+		return map[string]interface{}{"id": id, "name": name}
+	}
+
+	testCases := []struct {
+		name        string
+		tfTemplates []interface{}
+		expected    []string
+	}{
+		{"unchanged", []interface{}{tfTemplate("a", "A"), tfTemplate("b", "B")}, []string{"a", "b"}},
+		{"without ids", []interface{}{tfTemplate("", "A"), tfTemplate("", "B")}, []string{"a", "b"}},
+		{"renamed", []interface{}{tfTemplate("a", "A2"), tfTemplate("b", "B")}, []string{"a", "b"}},
+		{"inserted", []interface{}{tfTemplate("a", "C"), tfTemplate("b", "A"), tfTemplate("", "B")}, []string{"", "a", "b"}},
+		{"removed", []interface{}{tfTemplate("a", "B")}, []string{"b"}},
+		{"new", []interface{}{tfTemplate("", "C")}, []string{""}},
+	}
+
+	for _, testCase := range testCases {
+		actual := matchProjectTemplateIDs(testCase.tfTemplates, existingTemplates)
+
+		if strings.Join(actual, ",") != strings.Join(testCase.expected, ",") {
+			t.Errorf("%s: expected template ids %v, got %v", testCase.name, testCase.expected, actual)
+		}
+	}
+}
+
+func TestAccOctopusDeployProjectWithIISDeploymentTypes(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWithIISDeploymentTypes,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_iis_website.#", "3"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_iis_website.0.binding.#", "2"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_iis_website.0.binding.0.protocol", "https"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_iis_website.0.binding.0.require_sni", "true"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_iis_website.1.deployment_type", "virtualDirectory"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_iis_website.2.virtual_path", "/api"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithIISHTTPSBindingWithoutCertificate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWithIISHTTPSBindingWithoutCertificate,
+				ExpectError: regexp.MustCompile("must set exactly one of certificate_variable or thumbprint"),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithDeploymentStepPackageExtract(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWithDeploymentStepPackageExtract,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_package_extract.#", "2"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_package_extract.0.package", "Billing.Worker"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_package_extract.0.custom_installation_directory", "C:\\Billing\\Worker"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_package_extract.0.purge_custom_installation_directory", "true"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_package_extract.0.substitute_in_files.0.target_files.#", "2"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_package_extract.0.structured_variable_replacement.0.target_files.0", "config\\*.yaml"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_package_extract.0.custom_scripts_syntax", "PowerShell"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_package_extract.1.configuration_transforms", "false"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_package_extract.1.custom_scripts_syntax", "Bash"),
+				),
+			},
+			// removing a step from the configuration removes it from the deployment process
+			{
+				Config: testAccProjectBasic("Funky Monkey", "Lifecycles-1", "ProjectGroups-1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_package_extract.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithDeploymentStepManualIntervention(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWithDeploymentStepManualIntervention,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_manual_intervention.#", "1"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_manual_intervention.0.instructions", "Approve the change request before continuing."),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_manual_intervention.0.block_deployments", "true"),
+					resource.TestCheckResourceAttrPair(
+						terraformNamePrefix, "deployment_step_manual_intervention.0.environments.0", "octopusdeploy_environment.production", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithKubernetesDeploymentSteps(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWithKubernetesDeploymentSteps,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_kubernetes_containers.0.replicas", "3"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_kubernetes_containers.0.container_port.0.port", "8080"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_kubernetes_containers.0.environment_variables.ASPNETCORE_ENVIRONMENT", "Production"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_kubernetes_containers.0.service_port.0.target_port", "http"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_kubernetes_raw_yaml.0.namespace", "billing"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_helm_chart_upgrade.0.release_name", "billing-cache"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_helm_chart_upgrade.0.values_files.#", "2"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_helm_chart_upgrade.0.key_values.replicaCount", "2"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_kubectl_script.0.script_type", "Bash"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithCloudDeploymentSteps(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWithCloudDeploymentSteps,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_aws_cloudformation.0.parameters.Environment", "production"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_aws_cloudformation.0.capabilities.0", "CAPABILITY_IAM"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_aws_s3_upload.0.bucket_key", "releases/billing.zip"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_aws_cli_script.0.use_instance_role", "true"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_azure_app_service.0.deployment_slot", "staging"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_azure_resource_group.0.parameters.sku", "Standard"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_azure_script.0.azure_account_id", "Accounts-2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithAwsStepWithoutAccount(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWithAwsStepWithoutAccount,
+				ExpectError: regexp.MustCompile("must set exactly one of aws_account_variable or use_instance_role"),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithRollingDeployment(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWithRollingDeployment,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_inline_script.0.window_size", "1"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_iis_website.0.parent_step", "Rolling Web Farm Deployment"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_inline_script.1.parent_step", "Rolling Web Farm Deployment"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithChildStepWithOwnStepCondition(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWithChildStepWithOwnStepCondition,
+				ExpectError: regexp.MustCompile("must have the same step_condition as its parent step"),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithChildStepOfMissingParent(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWithChildStepOfMissingParent,
+				ExpectError: regexp.MustCompile("which is not a step of the project"),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithScopedDeploymentSteps(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWithScopedDeploymentSteps(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttrPair(
+						terraformNamePrefix, "deployment_step_package_extract.0.excluded_environments.0", "octopusdeploy_environment.production", "id"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_package_extract.0.disabled", "true"),
+					resource.TestCheckResourceAttrPair(
+						terraformNamePrefix, "deployment_step_manual_intervention.0.environments.0", "octopusdeploy_environment.production", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithStepIncludingAndExcludingEnvironment(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWithScopedDeploymentSteps(true),
+				ExpectError: regexp.MustCompile("cannot both run in and be skipped in environment"),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithVariableConditionStep(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWithVariableConditionStep("#{if Octopus.Release.Notes}True#{/if}"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_manual_intervention.0.step_condition", "variable"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_manual_intervention.0.condition_expression", "#{if Octopus.Release.Notes}True#{/if}"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_manual_intervention.0.package_requirement", "BeforePackageAcquisition"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithVariableConditionStepWithoutExpression(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWithVariableConditionStep(""),
+				ExpectError: regexp.MustCompile("must set condition_expression"),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithOrderedDeploymentSteps(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWithOrderedDeploymentSteps(1, 3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_inline_script.0.order", "1"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_windows_service.0.order", "2"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_inline_script.1.order", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithDeploymentStepsDeclaredOutOfOrder(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWithOrderedDeploymentSteps(3, 1),
+				ExpectError: regexp.MustCompile("must be declared in the order they run"),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithCustomScriptsOnServiceAndWebsiteSteps(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWithCustomScriptsOnServiceAndWebsiteSteps,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_windows_service.0.post_deploy_script", "Get-Service Billing | Select-Object Status"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_iis_website.0.custom_scripts_syntax", "Bash"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_iis_website.0.pre_deploy_script", "echo Deploying"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_iis_website.0.post_deploy_script_file_name", "scripts/smoke_test.sh"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithInlineAndPackageFileCustomScript(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWithInlineAndPackageFileCustomScript,
+				ExpectError: regexp.MustCompile("cannot set both deploy_script and deploy_script_file_name"),
+			},
+		},
+	})
+}
+
+func TestParsePackageFileScript(t *testing.T) {
+	for _, scriptSyntax := range customScriptSyntaxes {
+		script := fmt.Sprintf(scriptSyntax.packageFileScript, "scripts/deploy."+scriptSyntax.fileExtension)
+
+		if fileName, ok := parsePackageFileScript(scriptSyntax, script); !ok || fileName != "scripts/deploy."+scriptSyntax.fileExtension {
+			t.Errorf("%s: expected %q to run scripts/deploy.%s, got %q", scriptSyntax.syntax, script, scriptSyntax.fileExtension, fileName)
+		}
+
+		if fileName, ok := parsePackageFileScript(scriptSyntax, "echo Deploying"); ok {
+			t.Errorf("%s: expected an inline script not to run a package file, got %q", scriptSyntax.syntax, fileName)
+		}
+	}
+}
+
+func TestParsePackageFileScriptLookAlikes(t *testing.T) {
+	powerShell := getCustomScriptSyntax("PowerShell")
+
+	scripts := []string{
+		"& (Join-Path (Get-Location) '')",
+		"& (Join-Path (Get-Location) 'scripts\\Deploy.ps1')\nWrite-Host Done",
+		"Write-Host Starting\n& (Join-Path (Get-Location) 'scripts\\Deploy.ps1')",
+		"& (Join-Path (Get-Location) 'scripts\\Deploy.ps1') -Verbose",
+		"& (Join-Path (Get-Location) 'it's.ps1')",
+		"& (Join-Path $PWD 'scripts\\Deploy.ps1')",
+	}
+
+	for _, script := range scripts {
+		if fileName, ok := parsePackageFileScript(powerShell, script); ok {
+			t.Errorf("expected %q to be read back as an inline script, got file %q", script, fileName)
+		}
+	}
+
+	// known limitation: an inline script that is only the one line script is read back as the file it runs
+	if fileName, ok := parsePackageFileScript(powerShell, "& (Join-Path (Get-Location) 'scripts\\Deploy.ps1')"); !ok || fileName != "scripts\\Deploy.ps1" {
+		t.Errorf("expected the one line script to be read back as scripts\\Deploy.ps1, got %q", fileName)
+	}
+}
+
+func TestCustomScriptsDeploymentStepRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name      string
+		localStep map[string]interface{}
+	}{
+		{"inline", map[string]interface{}{"custom_scripts_syntax": "Bash", "pre_deploy_script": "echo Deploying", "post_deploy_script": ". \"$(pwd)/scripts/smoke_test.sh\" --quick"}},
+		{"package file", map[string]interface{}{"custom_scripts_syntax": "PowerShell", "deploy_script_file_name": "scripts\\Deploy.ps1"}},
+		{"both", map[string]interface{}{"custom_scripts_syntax": "CSharp", "deploy_script": "Console.WriteLine(\"Deploying\");", "post_deploy_script_file_name": "scripts/PostDeploy.csx"}},
+	}
+
+	for _, testCase := range testCases {
+		localStep := map[string]interface{}{}
+
+		for _, stage := range customScriptStages {
+			localStep[stage.attribute] = ""
+			localStep[stage.attribute+"_file_name"] = ""
+		}
+
+		for key, value := range testCase.localStep {
+			localStep[key] = value
+		}
+
+		deploymentStep := &octopusdeploy.DeploymentStep{
+			Name:    testCase.name,
+			Actions: []octopusdeploy.DeploymentAction{{Properties: map[string]string{}}},
+		}
+
+		if err := addCustomScriptsDeploymentStepProperties(localStep, deploymentStep); err != nil {
+			t.Errorf("%s: unexpected error %s", testCase.name, err)
+			continue
+		}
+
+		tfStep := map[string]interface{}{}
+		flattenCustomScriptsDeploymentStepProperties(deploymentStep.Actions[0], tfStep)
+
+		for key, value := range localStep {
+			if actual, _ := tfStep[key].(string); actual != value {
+				t.Errorf("%s: expected %s to be read back as %q, got %q", testCase.name, key, value, actual)
+			}
+		}
+
+		// scripts of a step with the feature turned off are not read back
+		deploymentStep.Actions[0].Properties["Octopus.Action.EnabledFeatures"] = ""
+		tfStep = map[string]interface{}{}
+		flattenCustomScriptsDeploymentStepProperties(deploymentStep.Actions[0], tfStep)
+
+		for _, stage := range customScriptStages {
+			if tfStep[stage.attribute] != nil || tfStep[stage.attribute+"_file_name"] != nil {
+				t.Errorf("%s: expected no scripts to be read back with the feature turned off, got %v", testCase.name, tfStep)
+			}
+		}
+	}
+}
+
+func TestCustomScriptsDeploymentStepFileNameWithQuotes(t *testing.T) {
+	localStep := map[string]interface{}{"custom_scripts_syntax": "PowerShell"}
+
+	for _, stage := range customScriptStages {
+		localStep[stage.attribute] = ""
+		localStep[stage.attribute+"_file_name"] = ""
+	}
+
+	localStep["deploy_script_file_name"] = "it's.ps1"
+
+	deploymentStep := &octopusdeploy.DeploymentStep{
+		Name:    "Deploy",
+		Actions: []octopusdeploy.DeploymentAction{{Properties: map[string]string{}}},
+	}
+
+	if err := addCustomScriptsDeploymentStepProperties(localStep, deploymentStep); err == nil {
+		t.Errorf("expected a file name with quotes to be rejected")
+	}
+}
+
+func TestAccOctopusDeployProjectWithWindowsServiceCustomAccount(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWithWindowsServiceCustomAccount("DOMAIN\\\\billing-svc"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_windows_service.0.custom_account_name", "DOMAIN\\billing-svc"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_windows_service.0.custom_account_password", "Sup3rS3cret!"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_windows_service.0.service_dependencies.1", "MSMQ"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_windows_service.0.service_desired_status", "Stopped"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_windows_service.0.custom_installation_directory", "C:\\Services\\Billing"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithWindowsServiceCustomAccountWithoutName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWithWindowsServiceCustomAccount(""),
+				ExpectError: regexp.MustCompile("must set custom_account_name"),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithStructuredVariableReplacement(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWithStructuredVariableReplacement(""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_iis_website.0.structured_variable_replacement.0.target_files.0", "appsettings.json"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_iis_website.0.structured_variable_replacement.0.target_files.1", "config/*.yaml"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithLegacyAndStructuredVariableReplacement(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWithStructuredVariableReplacement("appsettings.json"),
+				ExpectError: regexp.MustCompile("cannot set both json_file_variable_replacement and structured_variable_replacement"),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithSubstituteInFiles(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWithSubstituteInFiles,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_windows_service.0.substitute_in_files.0.target_files.0", "*.config"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_windows_service.0.substitute_in_files.0.output_encoding", "utf-8"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_package_script.0.substitute_in_files.0.target_files.0", "deploy.sh"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_package_script.0.substitute_in_files.0.warn_if_no_match", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithOrchestrationDeploymentSteps(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_project.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWithOrchestrationDeploymentSteps(`to = "oncall@example.com"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProjectExists(terraformNamePrefix),
+					resource.TestCheckResourceAttrPair(
+						terraformNamePrefix, "deployment_step_deploy_release.0.project_id", "octopusdeploy_project.billing", "id"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_deploy_release.0.deployment_condition", "IfNewer"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_deploy_release.0.variables.MaintenanceWindow", "30"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_health_check.0.include_new_machines", "true"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_email.0.to", "oncall@example.com"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_email.0.priority", "High"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployProjectWithEmailStepWithoutRecipients(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWithOrchestrationDeploymentSteps(""),
+				ExpectError: regexp.MustCompile("must set at least one of to or to_teams"),
+			},
+		},
+	})
+}
+
 func testAccProjectBasic(name, lifeCycleID, projectGroupID string) string {
 	return fmt.Sprintf(`
 		resource "octopusdeploy_project" "foo" {
@@ -232,87 +1006,884 @@ func testAccProjectBasic(name, lifeCycleID, projectGroupID string) string {
 	)
 }
 
-const testAccWithMultipleDeploymentStepWindowsService = `
+func testAccProjectWithProjectSettings(name, lifeCycleID, projectGroupID, versionTemplate string) string {
+	return fmt.Sprintf(`
+		resource "octopusdeploy_project" "foo" {
+			name                                 = "%s"
+			lifecycle_id                         = "%s"
+			project_group_id                     = "%s"
+			allow_deployments_to_no_targets      = true
+			default_to_skip_if_already_installed = true
+			discrete_channel_release             = true
+			is_disabled                          = true
+			tenanted_deployment_mode             = "TenantedOrUntenanted"
+
+			versioning_strategy {
+				template = "%s"
+			}
+		}
+		`,
+		name, lifeCycleID, projectGroupID, versionTemplate,
+	)
+}
+
+const testAccWithMultipleDeploymentStepWindowsService = `
+resource "octopusdeploy_project" "foo" {
+	name             = "Project Name"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+	description      = "My Awesome Description"
+
+	deployment_step_windows_service {
+		executable_path          = "C:\\MyService\\my_service.exe"
+		order                    = 1
+		package                  = "MyPackage"
+		service_name             = "My First Service"
+		step_name                = "Deploy My First Service"
+
+		target_roles = [
+		  "Role1",
+		  "Role2"
+		]
+	}
+
+	deployment_step_windows_service {
+		configuration_transforms = false
+		configuration_variables  = false
+		executable_path          = "C:\\MyService\\my_service2.exe"
+		order                    = 2
+		package                  = "MyServicePackage"
+		service_account          = "NewServiceAccount"
+		service_name             = "My Second Service"
+		service_start_mode       = "demand"
+		step_name                = "Deploy My Second Service"
+		step_start_trigger       = "StartWithPrevious"
+
+		target_roles = [
+		  "Role3",
+		]
+	}
+
+	deployment_step_iis_website {
+		anonymous_authentication       = true
+		application_pool_framework     = "v2.0"
+		application_pool_name          = "MyAppPool"
+		basic_authentication           = true
+		json_file_variable_replacement = "appsettings.json,Config\\*.json"
+		order                          = 3
+		package                        = "MyWebsitePackage"
+		step_condition                 = "failure"
+		step_name                      = "Deploy Website"
+		website_name                   = "Awesome Website"
+
+		target_roles = [
+		  "MyRole1",
+		]
+	}
+
+	deployment_step_inline_script {
+		step_name   = "Run Cleanup Script"
+		order       = 4
+		script_type = "PowerShell"
+
+		script_body = <<EOF
+	$x = Get-Process
+	foreach ($p in $x) {
+		Write-Output $p.Name
+	}
+	EOF
+
+		target_roles = [
+		  "MyRole1",
+		  "MyRole2",
+		]
+	}
+
+	deployment_step_package_script {
+		step_name         = "Run Verify From Package Script"
+		order             = 5
+		package           = "cleanup.yolo"
+		script_file_name  = "bin\\cleanup.ps1"
+		script_parameters = "-Force"
+
+		target_roles = [
+		  "MyRole1",
+		  "MyRole2",
+		]
+	  }
+}
+`
+
+const testAccWithIISDeploymentTypes = `
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
+	deployment_step_iis_website {
+		step_name             = "Deploy Website"
+		website_name          = "Awesome Website"
+		application_pool_name = "MyAppPool"
+		package               = "MyWebsitePackage"
+
+		binding {
+			protocol             = "https"
+			port                 = "443"
+			host                 = "awesome.example.com"
+			certificate_variable = "Website.Certificate"
+			require_sni          = true
+		}
+
+		binding {
+			port    = "#{Website.HttpPort}"
+			enabled = false
+		}
+
+		target_roles = [
+		  "MyRole1",
+		]
+	}
+
+	deployment_step_iis_website {
+		step_name           = "Deploy Docs"
+		deployment_type     = "virtualDirectory"
+		parent_website_name = "Awesome Website"
+		virtual_path        = "/docs"
+		package             = "MyDocsPackage"
+
+		target_roles = [
+		  "MyRole1",
+		]
+	}
+
+	deployment_step_iis_website {
+		step_name             = "Deploy API"
+		deployment_type       = "webApplication"
+		parent_website_name   = "Awesome Website"
+		virtual_path          = "/api"
+		application_pool_name = "MyApiAppPool"
+		package               = "MyApiPackage"
+
+		target_roles = [
+		  "MyRole1",
+		]
+	}
+}
+`
+
+const testAccWithIISHTTPSBindingWithoutCertificate = `
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
+	deployment_step_iis_website {
+		step_name             = "Deploy Website"
+		website_name          = "Awesome Website"
+		application_pool_name = "MyAppPool"
+		package               = "MyWebsitePackage"
+
+		binding {
+			protocol = "https"
+			port     = "443"
+		}
+
+		target_roles = [
+		  "MyRole1",
+		]
+	}
+}
+`
+
+const testAccWithDeploymentStepPackageExtract = `
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
+	deployment_step_package_extract {
+		step_name                           = "Deploy Billing Worker"
+		package                             = "Billing.Worker"
+		custom_installation_directory       = "C:\\Billing\\Worker"
+		purge_custom_installation_directory = true
+
+		substitute_in_files {
+			target_files = [
+				"*.config",
+				"scripts\\*.ps1",
+			]
+		}
+
+		structured_variable_replacement {
+			target_files = [
+				"config\\*.yaml",
+			]
+		}
+
+		pre_deploy_script = "Stop-ScheduledTask -TaskName 'Billing Worker'"
+		post_deploy_script = "Start-ScheduledTask -TaskName 'Billing Worker'"
+
+		target_roles = [
+		  "Billing-Worker",
+		]
+	}
+
+	deployment_step_package_extract {
+		step_name                = "Deploy Billing Reports"
+		package                  = "Billing.Reports"
+		configuration_transforms = false
+		configuration_variables  = false
+		custom_scripts_syntax    = "Bash"
+		deploy_script            = "./install.sh"
+
+		target_roles = [
+		  "Billing-Reports",
+		]
+	}
+}
+`
+
+const testAccWithDeploymentStepManualIntervention = `
+resource "octopusdeploy_environment" "production" {
+	name = "Manual Intervention Production"
+}
+
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
+	deployment_step_manual_intervention {
+		step_name         = "Change Control Approval"
+		instructions      = "Approve the change request before continuing."
+		block_deployments = true
+
+		environments = [
+			"${octopusdeploy_environment.production.id}",
+		]
+	}
+}
+`
+
+const testAccWithKubernetesDeploymentSteps = `
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
+	deployment_step_kubernetes_containers {
+		step_name       = "Deploy Billing API"
+		order           = 1
+		deployment_name = "billing-api"
+		namespace       = "billing"
+		replicas        = 3
+		container_name  = "api"
+		feed_id         = "feeds-builtin"
+		package         = "billing/api"
+		service_name    = "billing-api"
+
+		container_port {
+			name = "http"
+			port = 8080
+		}
+
+		environment_variables {
+			ASPNETCORE_ENVIRONMENT = "Production"
+		}
+
+		service_port {
+			name        = "http"
+			port        = 80
+			target_port = "http"
+		}
+
+		target_roles = [
+		  "Billing-Cluster",
+		]
+	}
+
+	deployment_step_kubernetes_raw_yaml {
+		step_name = "Deploy Billing Config"
+		order     = 2
+		namespace = "billing"
+
+		yaml = <<EOF
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: billing-config
+data:
+  region: asia
+EOF
+
+		target_roles = [
+		  "Billing-Cluster",
+		]
+	}
+
+	deployment_step_helm_chart_upgrade {
+		step_name    = "Upgrade Billing Cache"
+		order        = 3
+		release_name = "billing-cache"
+		namespace    = "billing"
+		package      = "redis"
+
+		values_files = [
+			"values.yaml",
+			"values.production.yaml",
+		]
+
+		key_values {
+			replicaCount = "2"
+		}
+
+		target_roles = [
+		  "Billing-Cluster",
+		]
+	}
+
+	deployment_step_kubectl_script {
+		step_name   = "Check Billing Pods"
+		order       = 4
+		script_type = "Bash"
+		script_body = "kubectl get pods --namespace billing"
+
+		target_roles = [
+		  "Billing-Cluster",
+		]
+	}
+}
+`
+
+const testAccWithCloudDeploymentSteps = `
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
+	deployment_step_aws_cloudformation {
+		step_name            = "Deploy Billing Stack"
+		order                = 1
+		aws_account_variable = "AWS.Account"
+		region               = "ap-southeast-2"
+		stack_name           = "billing"
+		template_body        = "{\"Resources\": {}}"
+
+		parameters {
+			Environment = "production"
+		}
+
+		capabilities = [
+			"CAPABILITY_IAM",
+		]
+	}
+
+	deployment_step_aws_s3_upload {
+		step_name            = "Upload Billing Package"
+		order                = 2
+		aws_account_variable = "AWS.Account"
+		region               = "ap-southeast-2"
+		bucket_name          = "billing-releases"
+		bucket_key           = "releases/billing.zip"
+		package              = "Billing.API"
+	}
+
+	deployment_step_aws_cli_script {
+		step_name         = "List Billing Buckets"
+		order             = 3
+		use_instance_role = true
+		region            = "ap-southeast-2"
+		script_type       = "Bash"
+		script_body       = "aws s3 ls"
+	}
+
+	deployment_step_azure_app_service {
+		step_name           = "Deploy Billing Web"
+		order               = 4
+		azure_account_id    = "Accounts-2"
+		resource_group_name = "billing"
+		web_app_name        = "billing-web"
+		deployment_slot     = "staging"
+		package             = "Billing.Web"
+	}
+
+	deployment_step_azure_resource_group {
+		step_name           = "Deploy Billing Resources"
+		order               = 5
+		azure_account_id    = "Accounts-2"
+		resource_group_name = "billing"
+		template_body       = "{\"resources\": []}"
+
+		parameters {
+			sku = "Standard"
+		}
+	}
+
+	deployment_step_azure_script {
+		step_name        = "Swap Billing Slots"
+		order            = 6
+		azure_account_id = "Accounts-2"
+		script_type      = "PowerShell"
+		script_body      = "Switch-AzureRmWebAppSlot -ResourceGroupName billing -Name billing-web -SourceSlotName staging"
+	}
+}
+`
+
+const testAccWithAwsStepWithoutAccount = `
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
+	deployment_step_aws_cli_script {
+		step_name   = "List Billing Buckets"
+		region      = "ap-southeast-2"
+		script_type = "Bash"
+		script_body = "aws s3 ls"
+	}
+}
+`
+
+const testAccWithCustomScriptsOnServiceAndWebsiteSteps = `
 resource "octopusdeploy_project" "foo" {
-	name             = "Project Name"
+	name             = "Funky Monkey"
 	lifecycle_id     = "Lifecycles-1"
 	project_group_id = "ProjectGroups-1"
-	description      = "My Awesome Description"
 
 	deployment_step_windows_service {
-		executable_path          = "C:\\MyService\\my_service.exe"
-		package                  = "MyPackage"
-		service_name             = "My First Service"
-		step_name                = "Deploy My First Service"
+		step_name          = "Deploy Billing Service"
+		order              = 1
+		service_name       = "Billing"
+		executable_path    = "Billing.exe"
+		package            = "Billing.Service"
+		post_deploy_script = "Get-Service Billing | Select-Object Status"
 
 		target_roles = [
-		  "Role1",
-		  "Role2"
+			"Billing",
 		]
 	}
 
+	deployment_step_iis_website {
+		step_name                    = "Deploy Website"
+		order                        = 2
+		website_name                 = "Billing"
+		application_pool_name        = "Billing"
+		package                      = "Billing.Web"
+		custom_scripts_syntax        = "Bash"
+		pre_deploy_script            = "echo Deploying"
+		post_deploy_script_file_name = "scripts/smoke_test.sh"
+
+		target_roles = [
+			"Billing",
+		]
+	}
+}
+`
+
+const testAccWithInlineAndPackageFileCustomScript = `
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
 	deployment_step_windows_service {
-		configuration_transforms = false
-		configuration_variables  = false
-		executable_path          = "C:\\MyService\\my_service2.exe"
-		package                  = "MyServicePackage"
-		service_account          = "NewServiceAccount"
-		service_name             = "My Second Service"
-		service_start_mode       = "demand"
-		step_name                = "Deploy My Second Service"
-		step_start_trigger       = "StartWithPrevious"
+		step_name               = "Deploy Billing Service"
+		service_name            = "Billing"
+		executable_path         = "Billing.exe"
+		package                 = "Billing.Service"
+		deploy_script           = "Write-Host Deploying"
+		deploy_script_file_name = "scripts\\Deploy.ps1"
 
 		target_roles = [
-		  "Role3",
+			"Billing",
+		]
+	}
+}
+`
+
+const testAccWithSubstituteInFiles = `
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
+	deployment_step_windows_service {
+		step_name       = "Deploy Billing Service"
+		order           = 1
+		service_name    = "Billing"
+		executable_path = "Billing.exe"
+		package         = "Billing.Service"
+
+		substitute_in_files {
+			target_files    = ["*.config"]
+			output_encoding = "utf-8"
+		}
+
+		target_roles = [
+			"Billing",
+		]
+	}
+
+	deployment_step_package_script {
+		step_name        = "Migrate Billing Database"
+		order            = 2
+		script_file_name = "deploy.sh"
+		package          = "Billing.Migrations"
+		run_on_server    = true
+
+		substitute_in_files {
+			target_files     = ["deploy.sh"]
+			warn_if_no_match = false
+		}
+	}
+}
+`
+
+const testAccWithRollingDeployment = `
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
+	deployment_step_inline_script {
+		step_name   = "Rolling Web Farm Deployment"
+		order       = 1
+		window_size = "1"
+		script_type = "PowerShell"
+		script_body = "Remove-WebFarmMember -Name $env:COMPUTERNAME"
+
+		target_roles = [
+		  "Web-Farm",
 		]
 	}
 
 	deployment_step_iis_website {
-		anonymous_authentication       = true
-		application_pool_framework     = "v2.0"
-		application_pool_name          = "MyAppPool"
-		basic_authentication           = true
-		json_file_variable_replacement = "appsettings.json,Config\\*.json"
-		package                        = "MyWebsitePackage"
-		step_condition                 = "failure"
-		step_name                      = "Deploy Website"
-		website_name                   = "Awesome Website"
+		step_name             = "Deploy Website"
+		order                 = 1
+		parent_step           = "Rolling Web Farm Deployment"
+		website_name          = "Awesome Website"
+		application_pool_name = "MyAppPool"
+		package               = "MyWebsitePackage"
+	}
+
+	deployment_step_inline_script {
+		step_name   = "Re-enable Web Farm Member"
+		order       = 2
+		parent_step = "Rolling Web Farm Deployment"
+		script_type = "PowerShell"
+		script_body = "Add-WebFarmMember -Name $env:COMPUTERNAME"
+	}
+}
+`
+
+const testAccWithChildStepWithOwnStepCondition = `
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
+	deployment_step_inline_script {
+		step_name   = "Rolling Web Farm Deployment"
+		window_size = "1"
+		script_type = "PowerShell"
+		script_body = "Remove-WebFarmMember -Name $env:COMPUTERNAME"
 
 		target_roles = [
-		  "MyRole1",
+		  "Web-Farm",
 		]
 	}
 
 	deployment_step_inline_script {
-		step_name   = "Run Cleanup Script"
+		step_name      = "Re-enable Web Farm Member"
+		parent_step    = "Rolling Web Farm Deployment"
+		step_condition = "always"
+		script_type    = "PowerShell"
+		script_body    = "Add-WebFarmMember -Name $env:COMPUTERNAME"
+	}
+}
+`
+
+const testAccWithChildStepOfMissingParent = `
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
+	deployment_step_inline_script {
+		step_name   = "Re-enable Web Farm Member"
+		parent_step = "Rolling Web Farm Deployment"
 		script_type = "PowerShell"
+		script_body = "Add-WebFarmMember -Name $env:COMPUTERNAME"
+	}
+}
+`
 
-		script_body = <<EOF
-	$x = Get-Process
-	foreach ($p in $x) {
-		Write-Output $p.Name
+func testAccWithTemplates(databaseNameTemplate string) string {
+	return fmt.Sprintf(`
+resource "octopusdeploy_project" "foo" {
+	name                     = "Funky Monkey"
+	lifecycle_id             = "Lifecycles-1"
+	project_group_id         = "ProjectGroups-1"
+	tenanted_deployment_mode = "Tenanted"
+
+	template {
+		name          = "%s"
+		label         = "Database Name"
+		help_text     = "The name of the tenant database."
+		default_value = "billing"
 	}
-	EOF
+
+	template {
+		name                    = "Tenant.Database.Password"
+		label                   = "Database Password"
+		control_type            = "Sensitive"
+		default_sensitive_value = "hunter2"
+	}
+
+	template {
+		name          = "Tenant.Region"
+		label         = "Region"
+		control_type  = "Select"
+		default_value = "asia"
+
+		select_option {
+			value        = "asia"
+			display_name = "Asia"
+		}
+
+		select_option {
+			value        = "europe"
+			display_name = "Europe"
+		}
+	}
+}
+`,
+		databaseNameTemplate,
+	)
+}
+
+func testAccWithOrchestrationDeploymentSteps(emailRecipients string) string {
+	return fmt.Sprintf(`
+resource "octopusdeploy_project" "billing" {
+	name             = "Billing"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+}
+
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
+	deployment_step_deploy_release {
+		step_name            = "Deploy Billing"
+		order                = 1
+		project_id           = "${octopusdeploy_project.billing.id}"
+		deployment_condition = "IfNewer"
+
+		variables {
+			MaintenanceWindow = "30"
+		}
+	}
+
+	deployment_step_health_check {
+		step_name            = "Check Web Servers"
+		order                = 2
+		include_new_machines = true
 
 		target_roles = [
-		  "MyRole1",
-		  "MyRole2",
+			"Web",
 		]
 	}
 
-	deployment_step_package_script {
-		step_name         = "Run Verify From Package Script"
-		package           = "cleanup.yolo"
-		script_file_name  = "bin\\cleanup.ps1"
-		script_parameters = "-Force"
+	deployment_step_email {
+		step_name      = "Notify On-Call"
+		order          = 3
+		step_condition = "failure"
+		subject        = "#{Octopus.Project.Name} failed to deploy"
+		body           = "<p>#{Octopus.Deployment.Error}</p>"
+		is_html        = true
+		priority       = "High"
+		%s
+	}
+}
+`,
+		emailRecipients,
+	)
+}
+
+func testAccWithStructuredVariableReplacement(jsonFileVariableReplacement string) string {
+	return fmt.Sprintf(`
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
+	deployment_step_iis_website {
+		step_name                      = "Deploy Website"
+		website_name                   = "Billing"
+		application_pool_name          = "Billing"
+		package                        = "Billing.Web"
+		json_file_variable_replacement = "%s"
+
+		structured_variable_replacement {
+			target_files = [
+				"appsettings.json",
+				"config/*.yaml",
+			]
+		}
 
 		target_roles = [
-		  "MyRole1",
-		  "MyRole2",
+			"Billing",
 		]
-	  }
+	}
+}
+`,
+		jsonFileVariableReplacement,
+	)
+}
+
+func testAccWithWindowsServiceCustomAccount(customAccountName string) string {
+	return fmt.Sprintf(`
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
+	deployment_step_windows_service {
+		step_name                     = "Deploy Billing Service"
+		service_name                  = "Billing"
+		service_display_name          = "Billing Service"
+		service_description           = "Processes invoices."
+		executable_path               = "Billing.exe"
+		arguments                     = "--queue billing"
+		service_account               = "_CUSTOM"
+		custom_account_name           = "%s"
+		custom_account_password       = "Sup3rS3cret!"
+		service_desired_status        = "Stopped"
+		custom_installation_directory = "C:\\Services\\Billing"
+		package                       = "Billing.Service"
+
+		service_dependencies = [
+			"W3SVC",
+			"MSMQ",
+		]
+
+		target_roles = [
+			"Billing",
+		]
+	}
+}
+`,
+		customAccountName,
+	)
+}
+
+func testAccWithOrderedDeploymentSteps(stopMonitoringOrder, startMonitoringOrder int) string {
+	return fmt.Sprintf(`
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
+	deployment_step_inline_script {
+		step_name   = "Stop Monitoring"
+		order       = %d
+		script_type = "PowerShell"
+		script_body = "Suspend-Monitoring -Service Billing"
+	}
+
+	deployment_step_windows_service {
+		step_name       = "Deploy Billing Service"
+		order           = 2
+		service_name    = "Billing"
+		executable_path = "Billing.exe"
+		package         = "Billing.Service"
+
+		target_roles = [
+			"Billing",
+		]
+	}
+
+	deployment_step_inline_script {
+		step_name   = "Start Monitoring"
+		order       = %d
+		script_type = "PowerShell"
+		script_body = "Resume-Monitoring -Service Billing"
+	}
+}
+`,
+		stopMonitoringOrder,
+		startMonitoringOrder,
+	)
+}
+
+func testAccWithVariableConditionStep(conditionExpression string) string {
+	return fmt.Sprintf(`
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+
+	deployment_step_manual_intervention {
+		step_name            = "Approve Release Notes"
+		step_condition       = "variable"
+		condition_expression = "%s"
+		package_requirement  = "BeforePackageAcquisition"
+		instructions         = "Check the release notes before deploying."
+	}
+}
+`,
+		conditionExpression,
+	)
+}
+
+func testAccWithScopedDeploymentSteps(includeExcludedEnvironment bool) string {
+	packageStepEnvironments := ""
+
+	if includeExcludedEnvironment {
+		packageStepEnvironments = `environments = ["${octopusdeploy_environment.production.id}"]`
+	}
+
+	return fmt.Sprintf(`
+		resource "octopusdeploy_environment" "production" {
+			name = "Scoped Steps Production"
+		}
+
+		resource "octopusdeploy_project" "foo" {
+			name             = "Funky Monkey"
+			lifecycle_id     = "Lifecycles-1"
+			project_group_id = "ProjectGroups-1"
+
+			deployment_step_package_extract {
+				step_name = "Deploy Billing Worker"
+				package   = "Billing.Worker"
+				disabled  = true
+
+				%s
+
+				excluded_environments = [
+					"${octopusdeploy_environment.production.id}",
+				]
+
+				target_roles = [
+				  "Billing-Worker",
+				]
+			}
+
+			deployment_step_manual_intervention {
+				step_name    = "Change Control Approval"
+				instructions = "Approve the change request before continuing."
+
+				environments = [
+					"${octopusdeploy_environment.production.id}",
+				]
+			}
+		}
+		`,
+		packageStepEnvironments,
+	)
 }
-`
 
 func testAccWithDeploymentStepWindowsService(name, lifeCycleID, projectGroupID, serviceName, executablePath, stepName, packageName string, targetRoles []string) string {
 	return fmt.Sprintf(`
@@ -356,8 +1927,33 @@ func testAccCheckOctopusDeployProjectExists(n string) resource.TestCheckFunc {
 	}
 }
 
+// testAccCheckOctopusDeployProjectTemplateID checks the ID of the first template is the one recorded by an earlier step
+func testAccCheckOctopusDeployProjectTemplateID(n string, templateID *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		id := rs.Primary.Attributes["template.0.id"]
+
+		if *templateID != "" && *templateID != id {
+			return fmt.Errorf("Expected template id %s, got %s", *templateID, id)
+		}
+
+		*templateID = id
+
+		return nil
+	}
+}
+
 func destroyHelper(s *terraform.State, client *octopusdeploy.Client) error {
 	for _, r := range s.RootModule().Resources {
+		if r.Type != "octopusdeploy_project" {
+			continue
+		}
+
 		if _, err := client.Project.Get(r.Primary.ID); err != nil {
 			if err == octopusdeploy.ErrItemNotFound {
 				continue
@@ -371,6 +1967,10 @@ func destroyHelper(s *terraform.State, client *octopusdeploy.Client) error {
 
 func existsHelper(s *terraform.State, client *octopusdeploy.Client) error {
 	for _, r := range s.RootModule().Resources {
+		if r.Type != "octopusdeploy_project" {
+			continue
+		}
+
 		if _, err := client.Project.Get(r.Primary.ID); err != nil {
 			return fmt.Errorf("Received an error retrieving project %s", err)
 		}
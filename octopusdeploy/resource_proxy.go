@@ -0,0 +1,151 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceProxy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProxyCreate,
+		Read:   resourceProxyRead,
+		Update: resourceProxyUpdate,
+		Delete: resourceProxyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"host": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The DNS name or IP address of the proxy.",
+			},
+			"port": &schema.Schema{
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "The port the proxy listens on.",
+			},
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The username to authenticate with the proxy.",
+			},
+			"password": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password to authenticate with the proxy. Octopus never returns it, so changes made outside Terraform are not detected.",
+			},
+		},
+	}
+}
+
+// setProxyValues copies the values managed by Terraform onto a proxy. The password is only sent when it has
+// changed, as Octopus keeps the existing password when none is sent.
+func setProxyValues(d *schema.ResourceData, proxy *octopusdeploy.Proxy) {
+	proxy.Name = d.Get("name").(string)
+	proxy.Host = d.Get("host").(string)
+	proxy.Port = d.Get("port").(int)
+	proxy.Username = d.Get("username").(string)
+
+	if d.HasChange("password") {
+		password := d.Get("password").(string)
+
+		if password == "" {
+			proxy.Password = &octopusdeploy.SensitivePropertyValue{HasValue: false}
+		} else {
+			proxy.Password = &octopusdeploy.SensitivePropertyValue{HasValue: true, NewValue: &password}
+		}
+	}
+}
+
+func resourceProxyCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	newProxy := octopusdeploy.NewProxy(d.Get("name").(string), d.Get("host").(string), d.Get("port").(int))
+
+	setProxyValues(d, newProxy)
+
+	createdProxy, err := client.Proxy.Add(newProxy)
+
+	if err != nil {
+		return fmt.Errorf("error creating proxy: %s", err.Error())
+	}
+
+	d.SetId(createdProxy.ID)
+
+	return nil
+}
+
+func resourceProxyRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	proxyID := d.Id()
+
+	proxy, err := client.Proxy.Get(proxyID)
+
+	if err == octopusdeploy.ErrItemNotFound {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading proxy id %s: %s", proxyID, err.Error())
+	}
+
+	log.Printf("[DEBUG] proxy: %v", m)
+	d.Set("name", proxy.Name)
+	d.Set("host", proxy.Host)
+	d.Set("port", proxy.Port)
+	d.Set("username", proxy.Username)
+
+	// the password is kept from the state, unless it was removed in Octopus
+	if proxy.Password != nil && !proxy.Password.HasValue {
+		d.Set("password", "")
+	}
+
+	return nil
+}
+
+func resourceProxyUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	// start from the proxy as it is in Octopus, so an unchanged password is sent back as it is
+	proxy, err := client.Proxy.Get(d.Id())
+
+	if err != nil {
+		return fmt.Errorf("error reading proxy id %s: %s", d.Id(), err.Error())
+	}
+
+	setProxyValues(d, proxy)
+
+	proxy, err = client.Proxy.Update(proxy)
+
+	if err != nil {
+		return fmt.Errorf("error updating proxy id %s: %s", d.Id(), err.Error())
+	}
+
+	d.SetId(proxy.ID)
+
+	return nil
+}
+
+func resourceProxyDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	proxyID := d.Id()
+
+	err := client.Proxy.Delete(proxyID)
+
+	if err != nil {
+		return fmt.Errorf("error deleting proxy id %s: %s", proxyID, err.Error())
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,101 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccOctopusDeployProxyBasic(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_proxy.foo"
+	const dataNamePrefix = "data.octopusdeploy_proxy.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployProxyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProxyBasic(8080, "s3cr3t"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProxyExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "host", "proxy.dmz.example.com"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "port", "8080"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "username", "octopus"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "password", "s3cr3t"),
+					resource.TestCheckResourceAttrPair(
+						dataNamePrefix, "id", terraformNamePrefix, "id"),
+					resource.TestCheckResourceAttr(
+						dataNamePrefix, "port", "8080"),
+				),
+			},
+			{
+				Config: testAccProxyBasic(3128, "n3w-s3cr3t"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployProxyExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "port", "3128"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "password", "n3w-s3cr3t"),
+				),
+			},
+		},
+	})
+}
+
+func testAccProxyBasic(port int, password string) string {
+	return fmt.Sprintf(`
+		resource "octopusdeploy_proxy" "foo" {
+			name     = "DMZ Proxy"
+			host     = "proxy.dmz.example.com"
+			port     = %d
+			username = "octopus"
+			password = "%s"
+		}
+
+		data "octopusdeploy_proxy" "foo" {
+			name = "${octopusdeploy_proxy.foo.name}"
+		}
+		`,
+		port, password,
+	)
+}
+
+func testAccCheckOctopusDeployProxyDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*octopusdeploy.Client)
+
+	for _, r := range s.RootModule().Resources {
+		if _, err := client.Proxy.Get(r.Primary.ID); err != nil {
+			if err == octopusdeploy.ErrItemNotFound {
+				continue
+			}
+			return fmt.Errorf("Received an error retrieving proxy %s", err)
+		}
+		return fmt.Errorf("Proxy still exists")
+	}
+	return nil
+}
+
+func testAccCheckOctopusDeployProxyExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*octopusdeploy.Client)
+
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if _, err := client.Proxy.Get(rs.Primary.ID); err != nil {
+			return fmt.Errorf("Received an error retrieving proxy %s", err)
+		}
+
+		return nil
+	}
+}
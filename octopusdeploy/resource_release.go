@@ -0,0 +1,241 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceRelease() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceReleaseCreate,
+		Read:   resourceReleaseRead,
+		Update: resourceReleaseUpdate,
+		Delete: resourceReleaseDelete,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the project to create the release for.",
+			},
+			"channel_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the channel to create the release in. Defaults to the project's default channel.",
+			},
+			"version": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The version number of the release.",
+			},
+			"release_notes": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The release notes of the release.",
+			},
+			"selected_package": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The version of a package to use for a step in the release. Only the listed packages are read back.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"step_name": {
+							Type:        schema.TypeString,
+							Description: "The name of the step (action) that uses the package.",
+							Required:    true,
+						},
+						"package_reference_name": {
+							Type:        schema.TypeString,
+							Description: "The name of the package reference, for steps that use more than one package.",
+							Optional:    true,
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Description: "The version of the package.",
+							Required:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildReleaseResource(d *schema.ResourceData) *octopusdeploy.Release {
+	projectID := d.Get("project_id").(string)
+	version := d.Get("version").(string)
+
+	release := octopusdeploy.NewRelease(projectID, version)
+
+	if attr, ok := d.GetOk("channel_id"); ok {
+		release.ChannelID = attr.(string)
+	}
+
+	release.ReleaseNotes = d.Get("release_notes").(string)
+	release.SelectedPackages = buildSelectedPackages(d)
+
+	return release
+}
+
+// selectedPackageKey identifies the package of a step a selected package is the version of
+type selectedPackageKey struct {
+	actionName           string
+	packageReferenceName string
+}
+
+func getSelectedPackageKey(selectedPackage octopusdeploy.SelectedPackage) selectedPackageKey {
+	return selectedPackageKey{
+		actionName:           selectedPackage.ActionName,
+		packageReferenceName: selectedPackage.PackageReferenceName,
+	}
+}
+
+func buildSelectedPackages(d *schema.ResourceData) []octopusdeploy.SelectedPackage {
+	var selectedPackages []octopusdeploy.SelectedPackage
+
+	for _, raw := range d.Get("selected_package").([]interface{}) {
+		tfSelectedPackage := raw.(map[string]interface{})
+
+		selectedPackages = append(selectedPackages, octopusdeploy.SelectedPackage{
+			ActionName:           tfSelectedPackage["step_name"].(string),
+			PackageReferenceName: tfSelectedPackage["package_reference_name"].(string),
+			Version:              tfSelectedPackage["version"].(string),
+		})
+	}
+
+	return selectedPackages
+}
+
+// mergeSelectedPackages sets the versions of the configured packages on the packages of a release, keeping the
+// versions Octopus chose for the other packages
+func mergeSelectedPackages(existingPackages []octopusdeploy.SelectedPackage, configuredPackages []octopusdeploy.SelectedPackage) []octopusdeploy.SelectedPackage {
+	selectedPackages := append([]octopusdeploy.SelectedPackage{}, existingPackages...)
+
+	for _, configuredPackage := range configuredPackages {
+		found := false
+
+		for i := range selectedPackages {
+			if getSelectedPackageKey(selectedPackages[i]) == getSelectedPackageKey(configuredPackage) {
+				selectedPackages[i].Version = configuredPackage.Version
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			selectedPackages = append(selectedPackages, configuredPackage)
+		}
+	}
+
+	return selectedPackages
+}
+
+func resourceReleaseCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	newRelease := buildReleaseResource(d)
+
+	createdRelease, err := client.Release.Add(newRelease)
+
+	if err != nil {
+		return fmt.Errorf("error creating release %s: %s", newRelease.Version, err.Error())
+	}
+
+	d.SetId(createdRelease.ID)
+
+	return resourceReleaseRead(d, m)
+}
+
+func resourceReleaseRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	releaseID := d.Id()
+
+	release, err := client.Release.Get(releaseID)
+
+	if err == octopusdeploy.ErrItemNotFound {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading release id %s: %s", releaseID, err.Error())
+	}
+
+	log.Printf("[DEBUG] release: %v", m)
+	d.Set("project_id", release.ProjectID)
+	d.Set("channel_id", release.ChannelID)
+	d.Set("version", release.Version)
+	d.Set("release_notes", release.ReleaseNotes)
+
+	configuredPackages := make(map[selectedPackageKey]bool)
+
+	for _, selectedPackage := range buildSelectedPackages(d) {
+		configuredPackages[getSelectedPackageKey(selectedPackage)] = true
+	}
+
+	// only the packages in the config are read back, so versions Octopus chose for the other packages are not a change
+	var selectedPackages []interface{}
+
+	for _, selectedPackage := range release.SelectedPackages {
+		if !configuredPackages[getSelectedPackageKey(selectedPackage)] {
+			continue
+		}
+
+		selectedPackages = append(selectedPackages, map[string]interface{}{
+			"step_name":              selectedPackage.ActionName,
+			"package_reference_name": selectedPackage.PackageReferenceName,
+			"version":                selectedPackage.Version,
+		})
+	}
+
+	d.Set("selected_package", selectedPackages)
+
+	return nil
+}
+
+func resourceReleaseUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	// start from the release as it is in Octopus, so the snapshots it was created with are kept
+	release, err := client.Release.Get(d.Id())
+
+	if err != nil {
+		return fmt.Errorf("error reading release id %s: %s", d.Id(), err.Error())
+	}
+
+	release.ReleaseNotes = d.Get("release_notes").(string)
+	release.SelectedPackages = mergeSelectedPackages(release.SelectedPackages, buildSelectedPackages(d))
+
+	updatedRelease, err := client.Release.Update(release)
+
+	if err != nil {
+		return fmt.Errorf("error updating release id %s: %s", d.Id(), err.Error())
+	}
+
+	d.SetId(updatedRelease.ID)
+
+	return resourceReleaseRead(d, m)
+}
+
+func resourceReleaseDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	releaseID := d.Id()
+
+	err := client.Release.Delete(releaseID)
+
+	if err != nil {
+		return fmt.Errorf("error deleting release id %s: %s", releaseID, err.Error())
+	}
+
+	d.SetId("")
+	return nil
+}
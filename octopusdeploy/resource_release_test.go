@@ -0,0 +1,107 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccOctopusDeployReleaseBasic(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_release.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployReleaseDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccReleaseBasic("1.0.0", "First release"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployReleaseExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "version", "1.0.0"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "release_notes", "First release"),
+					resource.TestCheckResourceAttrSet(
+						terraformNamePrefix, "channel_id"),
+				),
+			},
+			// the package versions Octopus chose are not read back when selected_package is not set
+			{
+				Config:   testAccReleaseBasic("1.0.0", "First release"),
+				PlanOnly: true,
+			},
+			// release notes are updated in place
+			{
+				Config: testAccReleaseBasic("1.0.0", "Updated notes"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployReleaseExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "release_notes", "Updated notes"),
+				),
+			},
+		},
+	})
+}
+
+func testAccReleaseBasic(version, releaseNotes string) string {
+	return fmt.Sprintf(`
+		resource "octopusdeploy_project" "foo" {
+			name             = "Funky Release Project"
+			lifecycle_id     = "Lifecycles-1"
+			project_group_id = "ProjectGroups-1"
+
+			deployment_step_inline_script {
+				step_name     = "Say Hello"
+				script_type   = "PowerShell"
+				script_body   = "Write-Output 'Hello'"
+				run_on_server = true
+			}
+		}
+
+		resource "octopusdeploy_release" "foo" {
+			project_id    = "${octopusdeploy_project.foo.id}"
+			version       = "%s"
+			release_notes = "%s"
+		}
+		`,
+		version, releaseNotes,
+	)
+}
+
+func testAccCheckOctopusDeployReleaseDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*octopusdeploy.Client)
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "octopusdeploy_release" {
+			continue
+		}
+
+		if _, err := client.Release.Get(r.Primary.ID); err != nil {
+			if err == octopusdeploy.ErrItemNotFound {
+				continue
+			}
+			return fmt.Errorf("Received an error retrieving release %s", err)
+		}
+		return fmt.Errorf("Release still exists")
+	}
+	return nil
+}
+
+func testAccCheckOctopusDeployReleaseExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*octopusdeploy.Client)
+
+		r, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if _, err := client.Release.Get(r.Primary.ID); err != nil {
+			return fmt.Errorf("Received an error retrieving release %s", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,344 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceRunbook() *schema.Resource {
+	resource := &schema.Resource{
+		Create: resourceRunbookCreate,
+		Read:   resourceRunbookRead,
+		Update: resourceRunbookUpdate,
+		Delete: resourceRunbookDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"project_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"runbook_process_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"published_runbook_snapshot_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"environment_scope": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "All",
+				Description: "Which environments the runbook can run in. Specified limits it to the environments given.",
+				ValidateFunc: validateValueFunc([]string{
+					"All",
+					"Specified",
+					"FromProjectLifecycles",
+				}),
+			},
+			"environments": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The environments the runbook can run in, when environment_scope is Specified.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"multi_tenancy_mode": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Untenanted",
+				Description: "Whether runs of this runbook are tenanted, untenanted or either.",
+				ValidateFunc: validateValueFunc([]string{
+					"Untenanted",
+					"TenantedOrUntenanted",
+					"Tenanted",
+				}),
+			},
+			"default_failure_mode": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "EnvironmentDefault",
+				ValidateFunc: validateValueFunc([]string{
+					"EnvironmentDefault",
+					"Off",
+					"On",
+				}),
+			},
+			"skip_machine_behavior": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "None",
+				ValidateFunc: validateValueFunc([]string{
+					"SkipUnavailableMachines",
+					"None",
+				}),
+			},
+			"allow_deployments_to_no_targets": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow runs to be started when there are no deployment targets.",
+			},
+			"run_retention_policy": &schema.Schema{
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"quantity_to_keep": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     100,
+							Description: "The number of runs to keep per environment.",
+						},
+						"should_keep_forever": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Keep all runs, ignoring quantity_to_keep.",
+						},
+					},
+				},
+			},
+			"publish": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Publish a snapshot of the runbook whenever its process changes.",
+			},
+		},
+	}
+
+	addDeploymentStepsSchema(resource.Schema)
+
+	return resource
+}
+
+func buildRunbookResource(d *schema.ResourceData) (*octopusdeploy.Runbook, error) {
+	runbook := octopusdeploy.NewRunbook(d.Get("name").(string), d.Get("project_id").(string))
+
+	if err := setRunbookValues(d, runbook); err != nil {
+		return nil, err
+	}
+
+	return runbook, nil
+}
+
+// setRunbookValues copies the values managed by Terraform onto a runbook
+func setRunbookValues(d *schema.ResourceData, runbook *octopusdeploy.Runbook) error {
+	runbook.Name = d.Get("name").(string)
+	runbook.Description = d.Get("description").(string)
+	runbook.EnvironmentScope = d.Get("environment_scope").(string)
+	runbook.Environments = getSliceFromTerraformTypeList(d.Get("environments"))
+	runbook.MultiTenancyMode = d.Get("multi_tenancy_mode").(string)
+	runbook.DefaultGuidedFailureMode = d.Get("default_failure_mode").(string)
+	runbook.ConnectivityPolicy.SkipMachineBehavior = d.Get("skip_machine_behavior").(string)
+	runbook.ConnectivityPolicy.AllowDeploymentsToNoTargets = d.Get("allow_deployments_to_no_targets").(bool)
+
+	if runbook.EnvironmentScope == "Specified" && len(runbook.Environments) == 0 {
+		return fmt.Errorf("runbook %s must set environments as its environment_scope is Specified", runbook.Name)
+	}
+
+	if runbook.EnvironmentScope != "Specified" && len(runbook.Environments) > 0 {
+		return fmt.Errorf("runbook %s can only set environments when its environment_scope is Specified", runbook.Name)
+	}
+
+	if runbook.Environments == nil {
+		runbook.Environments = []string{}
+	}
+
+	if tfRetentionPolicies := d.Get("run_retention_policy").([]interface{}); len(tfRetentionPolicies) > 0 && tfRetentionPolicies[0] != nil {
+		tfRetentionPolicy := tfRetentionPolicies[0].(map[string]interface{})
+
+		runbook.RunRetentionPolicy.QuantityToKeep = tfRetentionPolicy["quantity_to_keep"].(int)
+		runbook.RunRetentionPolicy.ShouldKeepForever = tfRetentionPolicy["should_keep_forever"].(bool)
+	}
+
+	return nil
+}
+
+// hasDeploymentStepChanges returns whether any of the deployment steps have changed
+func hasDeploymentStepChanges(d *schema.ResourceData) bool {
+	for _, stepType := range deploymentStepTypes {
+		if d.HasChange(stepType.attribute) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// updateRunbookProcess sets the steps of the process of a runbook, and publishes a snapshot of the runbook when
+// publish is set and the process has changed or was never published
+func updateRunbookProcess(d *schema.ResourceData, client *octopusdeploy.Client, runbook *octopusdeploy.Runbook) error {
+	runbookProcess, err := client.RunbookProcess.Get(runbook.RunbookProcessID)
+
+	if err != nil {
+		return fmt.Errorf("error getting runbook process for runbook: %s", err.Error())
+	}
+
+	runbookProcess.Steps, err = buildDeploymentSteps(d)
+
+	if err != nil {
+		return err
+	}
+
+	runbookProcess, err = client.RunbookProcess.Update(runbookProcess)
+
+	if err != nil {
+		return fmt.Errorf("error updating runbook process for runbook: %s", err.Error())
+	}
+
+	d.Set("runbook_process_id", runbookProcess.ID)
+
+	if !d.Get("publish").(bool) {
+		return nil
+	}
+
+	if runbook.PublishedRunbookSnapshotID != "" && !d.HasChange("publish") && !hasDeploymentStepChanges(d) {
+		return nil
+	}
+
+	snapshot, err := client.Runbook.PublishSnapshot(&octopusdeploy.RunbookSnapshot{
+		Name:      fmt.Sprintf("Snapshot %s", time.Now().UTC().Format("20060102150405")),
+		Notes:     "Published by Terraform",
+		ProjectID: runbook.ProjectID,
+		RunbookID: runbook.ID,
+	})
+
+	if err != nil {
+		return fmt.Errorf("error publishing snapshot of runbook: %s", err.Error())
+	}
+
+	d.Set("published_runbook_snapshot_id", snapshot.ID)
+
+	return nil
+}
+
+func resourceRunbookCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	newRunbook, err := buildRunbookResource(d)
+
+	if err != nil {
+		return err
+	}
+
+	createdRunbook, err := client.Runbook.Add(newRunbook)
+
+	if err != nil {
+		return fmt.Errorf("error creating runbook: %s", err.Error())
+	}
+
+	d.SetId(createdRunbook.ID)
+
+	if err := updateRunbookProcess(d, client, createdRunbook); err != nil {
+		return fmt.Errorf("error creating runbook process: %s", err.Error())
+	}
+
+	return resourceRunbookRead(d, m)
+}
+
+func resourceRunbookRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	runbookID := d.Id()
+
+	runbook, err := client.Runbook.Get(runbookID)
+
+	if err == octopusdeploy.ErrItemNotFound {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading runbook id %s: %s", runbookID, err.Error())
+	}
+
+	log.Printf("[DEBUG] runbook: %v", m)
+	d.Set("name", runbook.Name)
+	d.Set("project_id", runbook.ProjectID)
+	d.Set("description", runbook.Description)
+	d.Set("runbook_process_id", runbook.RunbookProcessID)
+	d.Set("published_runbook_snapshot_id", runbook.PublishedRunbookSnapshotID)
+	d.Set("environment_scope", runbook.EnvironmentScope)
+	d.Set("environments", runbook.Environments)
+	d.Set("multi_tenancy_mode", runbook.MultiTenancyMode)
+	d.Set("default_failure_mode", runbook.DefaultGuidedFailureMode)
+	d.Set("skip_machine_behavior", runbook.ConnectivityPolicy.SkipMachineBehavior)
+	d.Set("allow_deployments_to_no_targets", runbook.ConnectivityPolicy.AllowDeploymentsToNoTargets)
+
+	d.Set("run_retention_policy", []interface{}{
+		map[string]interface{}{
+			"quantity_to_keep":    runbook.RunRetentionPolicy.QuantityToKeep,
+			"should_keep_forever": runbook.RunRetentionPolicy.ShouldKeepForever,
+		},
+	})
+
+	runbookProcess, err := client.RunbookProcess.Get(runbook.RunbookProcessID)
+
+	if err != nil {
+		return fmt.Errorf("error reading runbook process id %s: %s", runbook.RunbookProcessID, err.Error())
+	}
+
+	flattenDeploymentSteps(d, runbookProcess.Steps)
+
+	return nil
+}
+
+func resourceRunbookUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	// start from the runbook as it is in Octopus, so values Terraform does not manage are sent back unchanged
+	runbook, err := client.Runbook.Get(d.Id())
+
+	if err != nil {
+		return fmt.Errorf("error reading runbook id %s: %s", d.Id(), err.Error())
+	}
+
+	if err := setRunbookValues(d, runbook); err != nil {
+		return err
+	}
+
+	runbook, err = client.Runbook.Update(runbook)
+
+	if err != nil {
+		return fmt.Errorf("error updating runbook id %s: %s", d.Id(), err.Error())
+	}
+
+	if err := updateRunbookProcess(d, client, runbook); err != nil {
+		return fmt.Errorf("error updating runbook process: %s", err.Error())
+	}
+
+	return resourceRunbookRead(d, m)
+}
+
+func resourceRunbookDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	runbookID := d.Id()
+
+	err := client.Runbook.Delete(runbookID)
+
+	if err != nil {
+		return fmt.Errorf("error deleting runbook id %s: %s", runbookID, err.Error())
+	}
+
+	d.SetId("")
+	return nil
+}
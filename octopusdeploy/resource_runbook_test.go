@@ -0,0 +1,185 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccOctopusDeployRunbookBasic(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_runbook.foo"
+	const runbookName = "Restart Web Servers"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployRunbookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRunbookBasic(runbookName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployRunbookExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "name", runbookName),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "environment_scope", "All"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "multi_tenancy_mode", "Untenanted"),
+					resource.TestCheckResourceAttrSet(
+						terraformNamePrefix, "runbook_process_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployRunbookWithProcess(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_runbook.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployRunbookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRunbookWithProcess("Specified"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeployRunbookExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "environment_scope", "Specified"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "environments.0", "Environments-1"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "multi_tenancy_mode", "TenantedOrUntenanted"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "skip_machine_behavior", "SkipUnavailableMachines"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "run_retention_policy.0.quantity_to_keep", "10"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_inline_script.0.step_name", "Restart App Pool"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "deployment_step_email.0.step_name", "Notify Operations"),
+					resource.TestCheckResourceAttrSet(
+						terraformNamePrefix, "published_runbook_snapshot_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeployRunbookWithEnvironmentsNotSpecified(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeployRunbookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRunbookWithProcess("All"),
+				ExpectError: regexp.MustCompile("can only set environments when its environment_scope is Specified"),
+			},
+		},
+	})
+}
+
+func testAccRunbookBasic(name string) string {
+	return fmt.Sprintf(`
+		resource "octopusdeploy_project" "foo" {
+			name             = "Funky Monkey"
+			lifecycle_id     = "Lifecycles-1"
+			project_group_id = "ProjectGroups-1"
+		}
+
+		resource "octopusdeploy_runbook" "foo" {
+			name       = "%s"
+			project_id = "${octopusdeploy_project.foo.id}"
+		}
+		`,
+		name,
+	)
+}
+
+func testAccRunbookWithProcess(environmentScope string) string {
+	return fmt.Sprintf(`
+resource "octopusdeploy_project" "foo" {
+	name             = "Funky Monkey"
+	lifecycle_id     = "Lifecycles-1"
+	project_group_id = "ProjectGroups-1"
+}
+
+resource "octopusdeploy_runbook" "foo" {
+	name                  = "Restart Web Servers"
+	project_id            = "${octopusdeploy_project.foo.id}"
+	environment_scope     = "%s"
+	environments          = ["Environments-1"]
+	multi_tenancy_mode    = "TenantedOrUntenanted"
+	skip_machine_behavior = "SkipUnavailableMachines"
+	publish               = true
+
+	run_retention_policy {
+		quantity_to_keep = 10
+	}
+
+	deployment_step_inline_script {
+		step_name   = "Restart App Pool"
+		order       = 1
+		script_type = "PowerShell"
+		script_body = "Restart-WebAppPool -Name Web"
+
+		target_roles = [
+			"Web",
+		]
+	}
+
+	deployment_step_email {
+		step_name      = "Notify Operations"
+		order          = 2
+		step_condition = "failure"
+		subject        = "#{Octopus.Runbook.Name} failed"
+		body           = "The web servers could not be restarted."
+		to             = "ops@example.com"
+	}
+}
+`,
+		environmentScope,
+	)
+}
+
+func testAccCheckOctopusDeployRunbookDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*octopusdeploy.Client)
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "octopusdeploy_runbook" {
+			continue
+		}
+
+		if _, err := client.Runbook.Get(r.Primary.ID); err != nil {
+			if err == octopusdeploy.ErrItemNotFound {
+				continue
+			}
+			return fmt.Errorf("Received an error retrieving runbook %s", err)
+		}
+		return fmt.Errorf("Runbook still exists")
+	}
+
+	return destroyHelper(s, client)
+}
+
+func testAccCheckOctopusDeployRunbookExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*octopusdeploy.Client)
+
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if _, err := client.Runbook.Get(rs.Primary.ID); err != nil {
+			return fmt.Errorf("Received an error retrieving runbook %s", err)
+		}
+
+		return nil
+	}
+}
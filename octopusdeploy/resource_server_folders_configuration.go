@@ -0,0 +1,90 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// serverFoldersConfigurationID is the ID of the server folders, of which there is only one set per Octopus server
+const serverFoldersConfigurationID = "server-folders"
+
+func resourceServerFoldersConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceServerFoldersConfigurationUpdate,
+		Read:   resourceServerFoldersConfigurationRead,
+		Update: resourceServerFoldersConfigurationUpdate,
+		Delete: resourceServerConfigurationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"logs_directory": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The folder Octopus writes its server logs to.",
+			},
+			"artifacts_directory": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The folder Octopus keeps the artifacts collected by deployments in.",
+			},
+			"task_logs_directory": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The folder Octopus keeps the logs of server tasks in.",
+			},
+		},
+	}
+}
+
+func resourceServerFoldersConfigurationRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	serverFolders, err := client.Configuration.GetServerFolders()
+
+	if err != nil {
+		return fmt.Errorf("error reading server folders configuration: %s", err.Error())
+	}
+
+	log.Printf("[DEBUG] server folders configuration: %v", m)
+	d.Set("logs_directory", serverFolders.LogsDirectory)
+	d.Set("artifacts_directory", serverFolders.ArtifactsDirectory)
+	d.Set("task_logs_directory", serverFolders.TaskLogsDirectory)
+
+	return nil
+}
+
+func resourceServerFoldersConfigurationUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	// start from the folders in Octopus, so folders that are not set are left as they are
+	serverFolders, err := client.Configuration.GetServerFolders()
+
+	if err != nil {
+		return fmt.Errorf("error reading server folders configuration: %s", err.Error())
+	}
+
+	if attr, ok := d.GetOk("logs_directory"); ok {
+		serverFolders.LogsDirectory = attr.(string)
+	}
+
+	if attr, ok := d.GetOk("artifacts_directory"); ok {
+		serverFolders.ArtifactsDirectory = attr.(string)
+	}
+
+	if attr, ok := d.GetOk("task_logs_directory"); ok {
+		serverFolders.TaskLogsDirectory = attr.(string)
+	}
+
+	if _, err := client.Configuration.UpdateServerFolders(serverFolders); err != nil {
+		return fmt.Errorf("error updating server folders configuration: %s", err.Error())
+	}
+
+	d.SetId(serverFoldersConfigurationID)
+
+	return resourceServerFoldersConfigurationRead(d, m)
+}
@@ -0,0 +1,34 @@
+package octopusdeploy
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccOctopusDeployServerFoldersConfiguration(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_server_folders_configuration.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServerFoldersConfiguration,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "artifacts_directory", "C:\\Octopus\\Artifacts"),
+					resource.TestCheckResourceAttrSet(
+						terraformNamePrefix, "logs_directory"),
+					resource.TestCheckResourceAttrSet(
+						terraformNamePrefix, "task_logs_directory"),
+				),
+			},
+		},
+	})
+}
+
+const testAccServerFoldersConfiguration = `
+		resource "octopusdeploy_server_folders_configuration" "foo" {
+			artifacts_directory = "C:\\Octopus\\Artifacts"
+		}
+		`
@@ -0,0 +1,132 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// smtpConfigurationID is the ID of the SMTP settings, of which there is only one per Octopus server
+const smtpConfigurationID = "smtp"
+
+func resourceSmtpConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSmtpConfigurationUpdate,
+		Read:   resourceSmtpConfigurationRead,
+		Update: resourceSmtpConfigurationUpdate,
+		Delete: resourceServerConfigurationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"host": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The DNS name or IP address of the mail server.",
+			},
+			"port": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      25,
+				ValidateFunc: validateIntRangeFunc(1, 65535),
+				Description:  "The port the mail server listens on.",
+			},
+			"from_address": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The email address email is sent from.",
+			},
+			"enable_ssl": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Use SSL to connect to the mail server.",
+			},
+			"timeout": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     12000,
+				Description: "The number of milliseconds to wait for the mail server to respond.",
+			},
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The username to authenticate with the mail server.",
+			},
+			"password": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password to authenticate with the mail server. Octopus never returns it, so changes made outside Terraform are not detected.",
+			},
+		},
+	}
+}
+
+func resourceSmtpConfigurationRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	smtp, err := client.Configuration.GetSmtp()
+
+	if err != nil {
+		return fmt.Errorf("error reading smtp configuration: %s", err.Error())
+	}
+
+	log.Printf("[DEBUG] smtp configuration: %v", m)
+	d.Set("host", smtp.SmtpHost)
+	d.Set("port", smtp.SmtpPort)
+	d.Set("from_address", smtp.SendEmailFrom)
+	d.Set("enable_ssl", smtp.EnableSsl)
+	d.Set("timeout", smtp.Timeout)
+	d.Set("username", smtp.SmtpLogin)
+
+	// the password is kept from the state, unless it was removed in Octopus
+	if smtp.SmtpPassword != nil && !smtp.SmtpPassword.HasValue {
+		d.Set("password", "")
+	}
+
+	return nil
+}
+
+func resourceSmtpConfigurationUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	// start from the settings in Octopus, so an unchanged password is sent back as it is
+	smtp, err := client.Configuration.GetSmtp()
+
+	if err != nil {
+		return fmt.Errorf("error reading smtp configuration: %s", err.Error())
+	}
+
+	smtp.SmtpHost = d.Get("host").(string)
+	smtp.SmtpPort = d.Get("port").(int)
+	smtp.SendEmailFrom = d.Get("from_address").(string)
+	smtp.EnableSsl = d.Get("enable_ssl").(bool)
+	smtp.Timeout = d.Get("timeout").(int)
+	smtp.SmtpLogin = d.Get("username").(string)
+
+	if d.HasChange("password") {
+		password := d.Get("password").(string)
+
+		if password == "" {
+			smtp.SmtpPassword = &octopusdeploy.SensitivePropertyValue{HasValue: false}
+		} else {
+			smtp.SmtpPassword = &octopusdeploy.SensitivePropertyValue{HasValue: true, NewValue: &password}
+		}
+	}
+
+	if _, err := client.Configuration.UpdateSmtp(smtp); err != nil {
+		return fmt.Errorf("error updating smtp configuration: %s", err.Error())
+	}
+
+	d.SetId(smtpConfigurationID)
+
+	return resourceSmtpConfigurationRead(d, m)
+}
+
+// resourceServerConfigurationDelete only removes server settings from the state, as they always exist. The settings
+// are left as they are in Octopus.
+func resourceServerConfigurationDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,57 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccOctopusDeploySmtpConfiguration(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_smtp_configuration.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSmtpConfiguration(25, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "host", "smtp.example.com"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "port", "25"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "from_address", "octopus@example.com"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "enable_ssl", "false"),
+				),
+			},
+			{
+				Config: testAccSmtpConfiguration(587, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "port", "587"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "enable_ssl", "true"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "username", "octopus"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSmtpConfiguration(port int, enableSsl bool) string {
+	return fmt.Sprintf(`
+		resource "octopusdeploy_smtp_configuration" "foo" {
+			host         = "smtp.example.com"
+			port         = %d
+			from_address = "octopus@example.com"
+			enable_ssl   = %t
+			username     = "octopus"
+			password     = "s3cr3t"
+		}
+		`,
+		port, enableSsl,
+	)
+}
@@ -0,0 +1,291 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceSubscription() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSubscriptionCreate,
+		Read:   resourceSubscriptionRead,
+		Update: resourceSubscriptionUpdate,
+		Delete: resourceSubscriptionDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"is_disabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Stop sending notifications for this subscription.",
+			},
+			"event_groups": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only notify of events in these event groups.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"event_categories": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only notify of events in these event categories.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"project_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only notify of events related to these projects.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"environment_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only notify of events related to these environments.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tenant_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only notify of events related to these tenants.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tenant_tags": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only notify of events related to tenants with these tags.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"webhook_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The URL events are posted to.",
+			},
+			"webhook_timeout": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     10,
+				Description: "The number of seconds to wait for the webhook to respond.",
+			},
+			"webhook_team_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only post events the members of these teams can see to the webhook.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"email_team_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The teams the email digest is sent to.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"email_frequency": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     60,
+				Description: "The number of minutes between email digests.",
+			},
+			"email_priority": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Normal",
+				ValidateFunc: validateValueFunc(octopusdeploy.ValidSubscriptionEmailPriorities),
+			},
+		},
+	}
+}
+
+func buildSubscriptionResource(d *schema.ResourceData) (*octopusdeploy.Subscription, error) {
+	subscription := octopusdeploy.NewSubscription(d.Get("name").(string))
+
+	if err := setSubscriptionValues(d, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// setSubscriptionValues copies the values managed by Terraform onto a subscription
+func setSubscriptionValues(d *schema.ResourceData, subscription *octopusdeploy.Subscription) error {
+	subscription.Name = d.Get("name").(string)
+	subscription.IsDisabled = d.Get("is_disabled").(bool)
+
+	eventGroups := getSliceFromTerraformTypeList(d.Get("event_groups"))
+
+	// need to validate here "ValidateFunc is not yet supported on lists or sets."
+	if invalidValue, ok := validateAllSliceItemsInSlice(eventGroups, octopusdeploy.ValidEventGroups); !ok {
+		return fmt.Errorf("Invalid value for event_groups. %s not in %v", invalidValue, octopusdeploy.ValidEventGroups)
+	}
+
+	eventCategories := getSliceFromTerraformTypeList(d.Get("event_categories"))
+
+	if invalidValue, ok := validateAllSliceItemsInSlice(eventCategories, octopusdeploy.ValidEventCategories); !ok {
+		return fmt.Errorf("Invalid value for event_categories. %s not in %v", invalidValue, octopusdeploy.ValidEventCategories)
+	}
+
+	webhookTimeout := d.Get("webhook_timeout").(int)
+
+	if webhookTimeout < 1 {
+		return fmt.Errorf("subscription %s must set a webhook_timeout of at least 1 second", subscription.Name)
+	}
+
+	emailFrequency := d.Get("email_frequency").(int)
+
+	if emailFrequency < 1 {
+		return fmt.Errorf("subscription %s must set an email_frequency of at least 1 minute", subscription.Name)
+	}
+
+	emailTeams := getSliceFromTerraformTypeList(d.Get("email_team_ids"))
+	webhookTeams := getSliceFromTerraformTypeList(d.Get("webhook_team_ids"))
+	webhookURL := d.Get("webhook_url").(string)
+
+	if webhookURL == "" && len(webhookTeams) > 0 {
+		return fmt.Errorf("subscription %s can only set webhook_team_ids when it sets webhook_url", subscription.Name)
+	}
+
+	if webhookURL == "" && len(emailTeams) == 0 {
+		return fmt.Errorf("subscription %s must set at least one of webhook_url or email_team_ids", subscription.Name)
+	}
+
+	subscription.EventNotificationSubscription.Filter.EventGroups = emptyIfNil(eventGroups)
+	subscription.EventNotificationSubscription.Filter.EventCategories = emptyIfNil(eventCategories)
+	subscription.EventNotificationSubscription.Filter.Projects = emptyIfNil(getSliceFromTerraformTypeList(d.Get("project_ids")))
+	subscription.EventNotificationSubscription.Filter.Environments = emptyIfNil(getSliceFromTerraformTypeList(d.Get("environment_ids")))
+	subscription.EventNotificationSubscription.Filter.Tenants = emptyIfNil(getSliceFromTerraformTypeList(d.Get("tenant_ids")))
+	subscription.EventNotificationSubscription.Filter.Tags = emptyIfNil(getSliceFromTerraformTypeList(d.Get("tenant_tags")))
+	subscription.EventNotificationSubscription.WebhookURI = webhookURL
+	subscription.EventNotificationSubscription.WebhookTimeout = formatTimeSpan(time.Duration(webhookTimeout) * time.Second)
+	subscription.EventNotificationSubscription.WebhookTeams = emptyIfNil(webhookTeams)
+	subscription.EventNotificationSubscription.EmailTeams = emptyIfNil(emailTeams)
+	subscription.EventNotificationSubscription.EmailFrequencyPeriod = formatTimeSpan(time.Duration(emailFrequency) * time.Minute)
+	subscription.EventNotificationSubscription.EmailPriority = d.Get("email_priority").(string)
+
+	return nil
+}
+
+func resourceSubscriptionCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	newSubscription, err := buildSubscriptionResource(d)
+
+	if err != nil {
+		return err
+	}
+
+	createdSubscription, err := client.Subscription.Add(newSubscription)
+
+	if err != nil {
+		return fmt.Errorf("error creating subscription: %s", err.Error())
+	}
+
+	d.SetId(createdSubscription.ID)
+
+	return nil
+}
+
+func resourceSubscriptionRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	subscriptionID := d.Id()
+
+	subscription, err := client.Subscription.Get(subscriptionID)
+
+	if err == octopusdeploy.ErrItemNotFound {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading subscription id %s: %s", subscriptionID, err.Error())
+	}
+
+	log.Printf("[DEBUG] subscription: %v", m)
+	d.Set("name", subscription.Name)
+	d.Set("is_disabled", subscription.IsDisabled)
+
+	eventNotification := subscription.EventNotificationSubscription
+
+	d.Set("event_groups", eventNotification.Filter.EventGroups)
+	d.Set("event_categories", eventNotification.Filter.EventCategories)
+	d.Set("project_ids", eventNotification.Filter.Projects)
+	d.Set("environment_ids", eventNotification.Filter.Environments)
+	d.Set("tenant_ids", eventNotification.Filter.Tenants)
+	d.Set("tenant_tags", eventNotification.Filter.Tags)
+	d.Set("webhook_url", eventNotification.WebhookURI)
+	d.Set("webhook_team_ids", eventNotification.WebhookTeams)
+	d.Set("email_team_ids", eventNotification.EmailTeams)
+	d.Set("email_priority", eventNotification.EmailPriority)
+
+	if webhookTimeout, err := parseTimeSpan(eventNotification.WebhookTimeout); err == nil {
+		d.Set("webhook_timeout", int(webhookTimeout/time.Second))
+	}
+
+	if emailFrequency, err := parseTimeSpan(eventNotification.EmailFrequencyPeriod); err == nil {
+		d.Set("email_frequency", int(emailFrequency/time.Minute))
+	}
+
+	return nil
+}
+
+func resourceSubscriptionUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	// start from the subscription as it is in Octopus, so values Terraform does not manage are sent back unchanged
+	subscription, err := client.Subscription.Get(d.Id())
+
+	if err != nil {
+		return fmt.Errorf("error reading subscription id %s: %s", d.Id(), err.Error())
+	}
+
+	if err := setSubscriptionValues(d, subscription); err != nil {
+		return err
+	}
+
+	subscription, err = client.Subscription.Update(subscription)
+
+	if err != nil {
+		return fmt.Errorf("error updating subscription id %s: %s", d.Id(), err.Error())
+	}
+
+	d.SetId(subscription.ID)
+
+	return nil
+}
+
+func resourceSubscriptionDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*octopusdeploy.Client)
+
+	subscriptionID := d.Id()
+
+	err := client.Subscription.Delete(subscriptionID)
+
+	if err != nil {
+		return fmt.Errorf("error deleting subscription id %s: %s", subscriptionID, err.Error())
+	}
+
+	d.SetId("")
+	return nil
+}
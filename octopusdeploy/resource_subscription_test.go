@@ -0,0 +1,144 @@
+package octopusdeploy
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccOctopusDeploySubscriptionWebhook(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_subscription.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeploySubscriptionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSubscriptionWebhook("DeploymentFailed", 30),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeploySubscriptionExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "name", "Failed Deployments"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "event_categories.0", "DeploymentFailed"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "environment_ids.0", "Environments-1"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "webhook_url", "https://hooks.example.com/octopus"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "webhook_timeout", "30"),
+				),
+			},
+			{
+				Config: testAccSubscriptionWebhook("DeploymentSucceeded", 10),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeploySubscriptionExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "event_categories.0", "DeploymentSucceeded"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "webhook_timeout", "10"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeploySubscriptionEmailDigest(t *testing.T) {
+	const terraformNamePrefix = "octopusdeploy_subscription.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeploySubscriptionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSubscriptionEmailDigest,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOctopusDeploySubscriptionExists(terraformNamePrefix),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "email_team_ids.0", "teams-everyone"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "email_frequency", "1440"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "email_priority", "High"),
+					resource.TestCheckResourceAttr(
+						terraformNamePrefix, "event_groups.0", "MachineCritical"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOctopusDeploySubscriptionWithInvalidEventCategory(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOctopusDeploySubscriptionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSubscriptionWebhook("DeploymentExploded", 10),
+				ExpectError: regexp.MustCompile("Invalid value for event_categories"),
+			},
+		},
+	})
+}
+
+func testAccSubscriptionWebhook(eventCategory string, webhookTimeout int) string {
+	return fmt.Sprintf(`
+		resource "octopusdeploy_subscription" "foo" {
+			name             = "Failed Deployments"
+			event_categories = ["%s"]
+			environment_ids  = ["Environments-1"]
+			webhook_url      = "https://hooks.example.com/octopus"
+			webhook_timeout  = %d
+		}
+		`,
+		eventCategory, webhookTimeout,
+	)
+}
+
+const testAccSubscriptionEmailDigest = `
+		resource "octopusdeploy_subscription" "foo" {
+			name            = "Unhealthy Machines"
+			event_groups    = ["MachineCritical"]
+			email_team_ids  = ["teams-everyone"]
+			email_frequency = 1440
+			email_priority  = "High"
+		}
+		`
+
+func testAccCheckOctopusDeploySubscriptionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*octopusdeploy.Client)
+
+	for _, r := range s.RootModule().Resources {
+		if _, err := client.Subscription.Get(r.Primary.ID); err != nil {
+			if err == octopusdeploy.ErrItemNotFound {
+				continue
+			}
+			return fmt.Errorf("Received an error retrieving subscription %s", err)
+		}
+		return fmt.Errorf("Subscription still exists")
+	}
+	return nil
+}
+
+func testAccCheckOctopusDeploySubscriptionExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*octopusdeploy.Client)
+
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if _, err := client.Subscription.Get(rs.Primary.ID); err != nil {
+			return fmt.Errorf("Received an error retrieving subscription %s", err)
+		}
+
+		return nil
+	}
+}
@@ -1,8 +1,12 @@
 package octopusdeploy
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/MattHodge/go-octopusdeploy/octopusdeploy"
 	"github.com/hashicorp/terraform/helper/mutexkv"
 	"github.com/hashicorp/terraform/helper/schema"
 )
@@ -11,6 +15,44 @@ import (
 // that we wait for other commands to finish first.
 var octoMutex = mutexkv.NewMutexKV()
 
+// taskPollInterval is how often a server task is checked while waiting for it to complete
+const taskPollInterval = 5 * time.Second
+
+// waitForServerTask blocks until a server task completes or the timeout passes. A task that does not succeed
+// returns an *octopusdeploy.TaskFailedError describing why.
+func waitForServerTask(client *octopusdeploy.Client, taskID string, timeout time.Duration) (*octopusdeploy.ServerTask, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return client.Task.WaitForCompletion(ctx, taskID, taskPollInterval)
+}
+
+// getServerTaskLog returns the activity log of a server task as text, for use in error messages
+func getServerTaskLog(client *octopusdeploy.Client, taskID string) string {
+	details, err := client.Task.GetDetails(taskID)
+
+	if err != nil {
+		return fmt.Sprintf("could not read the log of server task %s: %s", taskID, err.Error())
+	}
+
+	var logLines []string
+
+	var addActivityLogLines func(activities []octopusdeploy.ActivityElement)
+	addActivityLogLines = func(activities []octopusdeploy.ActivityElement) {
+		for _, activity := range activities {
+			for _, logElement := range activity.LogElements {
+				logLines = append(logLines, fmt.Sprintf("%s: %s", logElement.Category, logElement.MessageText))
+			}
+
+			addActivityLogLines(activity.Children)
+		}
+	}
+
+	addActivityLogLines(details.ActivityLogs)
+
+	return strings.Join(logLines, "\n")
+}
+
 // Validate a value against a set of possible values
 func validateValueFunc(values []string) schema.SchemaValidateFunc {
 	return func(v interface{}, k string) (we []string, errors []error) {
@@ -30,6 +72,30 @@ func validateValueFunc(values []string) schema.SchemaValidateFunc {
 	}
 }
 
+// validateIntRangeFunc checks an int argument is between min and max, inclusive
+func validateIntRangeFunc(min, max int) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (we []string, errors []error) {
+		value := v.(int)
+
+		if value < min || value > max {
+			errors = append(errors, fmt.Errorf("%d is an invalid value for argument %s. Must be between %d and %d", value, k, min, max))
+		}
+		return
+	}
+}
+
+// validateIntAtLeastFunc checks an int argument is at least min
+func validateIntAtLeastFunc(min int) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (we []string, errors []error) {
+		value := v.(int)
+
+		if value < min {
+			errors = append(errors, fmt.Errorf("%d is an invalid value for argument %s. Must be at least %d", value, k, min))
+		}
+		return
+	}
+}
+
 // validateStringInSlice checks if a string is in the given slice
 func validateStringInSlice(str string, list []string) bool {
 	for _, v := range list {
@@ -62,3 +128,50 @@ func getSliceFromTerraformTypeList(inputTypeList interface{}) []string {
 
 	return newSlice
 }
+
+// emptyIfNil returns an empty slice for a nil slice, for lists Octopus rejects as null
+func emptyIfNil(slice []string) []string {
+	if slice == nil {
+		return []string{}
+	}
+
+	return slice
+}
+
+// formatTimeSpan formats a duration as a .NET TimeSpan string, such as "01:00:00" or "1.00:00:00", which is how
+// Octopus sends and receives periods of time
+func formatTimeSpan(duration time.Duration) string {
+	days := int(duration / (24 * time.Hour))
+	duration -= time.Duration(days) * 24 * time.Hour
+
+	timeSpan := fmt.Sprintf("%02d:%02d:%02d", int(duration/time.Hour), int(duration%time.Hour/time.Minute), int(duration%time.Minute/time.Second))
+
+	if days > 0 {
+		return fmt.Sprintf("%d.%s", days, timeSpan)
+	}
+
+	return timeSpan
+}
+
+// parseTimeSpan parses a .NET TimeSpan string as returned by Octopus. Fractions of a second are ignored.
+func parseTimeSpan(timeSpan string) (time.Duration, error) {
+	var days, hours, minutes, seconds int
+
+	if i := strings.Index(timeSpan, "."); i >= 0 && i < strings.Index(timeSpan, ":") {
+		if _, err := fmt.Sscanf(timeSpan[:i], "%d", &days); err != nil {
+			return 0, fmt.Errorf("invalid time span %s: %s", timeSpan, err.Error())
+		}
+
+		timeSpan = timeSpan[i+1:]
+	}
+
+	if i := strings.Index(timeSpan, "."); i >= 0 {
+		timeSpan = timeSpan[:i]
+	}
+
+	if _, err := fmt.Sscanf(timeSpan, "%d:%d:%d", &hours, &minutes, &seconds); err != nil {
+		return 0, fmt.Errorf("invalid time span %s: %s", timeSpan, err.Error())
+	}
+
+	return time.Duration(days)*24*time.Hour + time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second, nil
+}
@@ -0,0 +1,99 @@
+package octopusdeploy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTimeSpan(t *testing.T) {
+	testCases := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{0, "00:00:00"},
+		{10 * time.Second, "00:00:10"},
+		{90 * time.Minute, "01:30:00"},
+		{23*time.Hour + 59*time.Minute + 59*time.Second, "23:59:59"},
+		{24 * time.Hour, "1.00:00:00"},
+		{36*time.Hour + 15*time.Second, "1.12:00:15"},
+		{14 * 24 * time.Hour, "14.00:00:00"},
+	}
+
+	for _, testCase := range testCases {
+		if actual := formatTimeSpan(testCase.duration); actual != testCase.expected {
+			t.Errorf("formatTimeSpan(%s): expected %s, got %s", testCase.duration, testCase.expected, actual)
+		}
+	}
+}
+
+func TestParseTimeSpan(t *testing.T) {
+	testCases := []struct {
+		timeSpan string
+		expected time.Duration
+	}{
+		{"00:00:00", 0},
+		{"00:00:10", 10 * time.Second},
+		{"01:30:00", 90 * time.Minute},
+		{"1.00:00:00", 24 * time.Hour},
+		{"1.12:00:15", 36*time.Hour + 15*time.Second},
+		{"14.00:00:00", 14 * 24 * time.Hour},
+		{"00:00:10.5000000", 10 * time.Second},
+		{"2.03:04:05.1230000", 51*time.Hour + 4*time.Minute + 5*time.Second},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := parseTimeSpan(testCase.timeSpan)
+
+		if err != nil {
+			t.Errorf("parseTimeSpan(%s): unexpected error %s", testCase.timeSpan, err)
+			continue
+		}
+
+		if actual != testCase.expected {
+			t.Errorf("parseTimeSpan(%s): expected %s, got %s", testCase.timeSpan, testCase.expected, actual)
+		}
+
+		// whole seconds survive being formatted and parsed again
+		if roundTripped, err := parseTimeSpan(formatTimeSpan(actual)); err != nil || roundTripped != actual {
+			t.Errorf("parseTimeSpan(formatTimeSpan(%s)): expected %s, got %s (%v)", actual, actual, roundTripped, err)
+		}
+	}
+}
+
+func TestValidateIntRangeFunc(t *testing.T) {
+	validatePort := validateIntRangeFunc(1, 65535)
+
+	for _, port := range []int{1, 25, 65535} {
+		if _, errors := validatePort(port, "port"); len(errors) > 0 {
+			t.Errorf("validateIntRangeFunc(1, 65535)(%d): unexpected errors %v", port, errors)
+		}
+	}
+
+	for _, port := range []int{-1, 0, 65536} {
+		if _, errors := validatePort(port, "port"); len(errors) == 0 {
+			t.Errorf("validateIntRangeFunc(1, 65535)(%d): expected an error", port)
+		}
+	}
+}
+
+func TestValidateIntAtLeastFunc(t *testing.T) {
+	validateDays := validateIntAtLeastFunc(0)
+
+	for _, days := range []int{0, 1, 365} {
+		if _, errors := validateDays(days, "days"); len(errors) > 0 {
+			t.Errorf("validateIntAtLeastFunc(0)(%d): unexpected errors %v", days, errors)
+		}
+	}
+
+	if _, errors := validateDays(-1, "days"); len(errors) == 0 {
+		t.Errorf("validateIntAtLeastFunc(0)(-1): expected an error")
+	}
+}
+
+func TestParseTimeSpanInvalid(t *testing.T) {
+	for _, timeSpan := range []string{"", "ten seconds", "x.00:00:10"} {
+		if _, err := parseTimeSpan(timeSpan); err == nil {
+			t.Errorf("parseTimeSpan(%q): expected an error", timeSpan)
+		}
+	}
+}